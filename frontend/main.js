@@ -1,8 +1,211 @@
+// Go bindings exposed by Wails
+const backend = window.go.app.App;
+
+// Word flagged by the backend that the action buttons apply to
+let flaggedWord = "";
+
+// Configuration being edited in the settings view
+let editedConfig = null;
+
 // Listen for text updates from Go backend
 window.runtime.EventsOn("updateText", (data) => {
     const status = document.getElementById("status");
     status.textContent = data.text;
     status.className = data.state || "waiting";
+
+    const suggestions = document.getElementById("suggestions");
+    suggestions.replaceChildren(
+        ...(data.suggestions || []).map((value) => {
+            const item = document.createElement("li");
+            item.textContent = value;
+            return item;
+        }),
+    );
+    suggestions.hidden = !data.suggestions || data.suggestions.length === 0;
+
+    const hint = document.getElementById("hint");
+    hint.textContent = data.hint || "";
+    hint.hidden = !data.hint;
+
+    flaggedWord = data.word || "";
+    document.getElementById("actions").hidden = flaggedWord === "";
+
+    const paused = data.state === "paused";
+    const togglePause = document.getElementById("toggle-pause");
+    togglePause.textContent = paused ? "▶" : "⏸";
+    togglePause.title = paused ? "Resume" : "Pause";
+});
+
+// Suspend or resume tracking, like the pause hotkey
+document.getElementById("toggle-pause").addEventListener("click", () => {
+    backend.TogglePause().catch(console.error);
+});
+
+// Add the flagged word to the personal dictionary
+document.getElementById("add-word").addEventListener("click", () => {
+    if (flaggedWord) {
+        backend.AddToDictionary(flaggedWord).catch(console.error);
+    }
+});
+
+// Stop flagging the word without suggesting it
+document.getElementById("ignore-word").addEventListener("click", () => {
+    if (flaggedWord) {
+        backend.IgnoreWord(flaggedWord).catch(console.error);
+    }
+});
+
+// Settings view
+
+const settingsForm = document.getElementById("settings");
+const settingsError = document.getElementById("settings-error");
+
+function showSettingsError(err) {
+    settingsError.textContent = err ? String(err) : "";
+    settingsError.hidden = !err;
+}
+
+async function openSettings() {
+    editedConfig = await backend.GetConfig();
+    const dictionaries = await backend.ListDictionaries();
+
+    const form = settingsForm.elements;
+    form.correction_mode.value = editedConfig.correction_mode;
+    form.min_score.value = editedConfig.min_score;
+    form.max_suggestions.value = editedConfig.max_suggestions;
+    form.word_timeout.value = editedConfig.word_timeout;
+    form.correction_delay.value = editedConfig.correction_delay;
+    form.undo_hotkey.value = editedConfig.undo_hotkey;
+    form.confirm_hotkey.value = editedConfig.confirm_hotkey;
+    form.pick_modifiers.value = editedConfig.pick_modifiers;
+    form.pause_hotkey.value = editedConfig.pause_hotkey;
+    form.secure_guard.checked = editedConfig.secure_guard;
+    form.grammar.checked = editedConfig.grammar;
+
+    // A layout file set in config.json is kept as an extra choice
+    const layout = form.keyboard_layout;
+    [...layout.options].filter((o) => o.dataset.custom).forEach((o) => o.remove());
+    if (![...layout.options].some((o) => o.value === editedConfig.keyboard_layout)) {
+        const option = new Option(editedConfig.keyboard_layout, editedConfig.keyboard_layout);
+        option.dataset.custom = "true";
+        layout.add(option);
+    }
+    layout.value = editedConfig.keyboard_layout;
+
+    const language = form.language;
+    language.replaceChildren();
+    const detect = document.getElementById("detect-languages");
+    detect.replaceChildren();
+    for (const dict of dictionaries) {
+        const option = new Option(`${dict.name} (${dict.code})`, dict.code);
+        option.disabled = !dict.available;
+        language.add(option);
+
+        const label = document.createElement("label");
+        label.className = "check";
+        const box = document.createElement("input");
+        box.type = "checkbox";
+        box.value = dict.code;
+        box.disabled = !dict.available;
+        box.checked = (editedConfig.detect_languages || []).includes(dict.code);
+        label.append(box, ` ${dict.name}`);
+        detect.append(label);
+    }
+    language.value = editedConfig.language;
+
+    await refreshPersonalWords();
+    showSettingsError(null);
+
+    document.getElementById("app").hidden = true;
+    settingsForm.hidden = false;
+    window.runtime.WindowSetSize(420, 640);
+}
+
+function closeSettings() {
+    settingsForm.hidden = true;
+    document.getElementById("app").hidden = false;
+    if (editedConfig) {
+        window.runtime.WindowSetSize(editedConfig.window.width, editedConfig.window.height);
+    }
+}
+
+async function refreshPersonalWords() {
+    const personal = await backend.GetPersonalWords();
+    const list = document.getElementById("personal-words");
+    list.replaceChildren();
+
+    const entries = [
+        ...personal.words.map((w) => [w, ""]),
+        ...personal.ignored.map((w) => [w, "ignored"]),
+        ...personal.learned.map((w) => [w, "learned"]),
+    ];
+    for (const [word, kind] of entries) {
+        const item = document.createElement("li");
+        item.textContent = kind ? `${word} (${kind})` : word;
+
+        const remove = document.createElement("button");
+        remove.type = "button";
+        remove.textContent = "×";
+        remove.title = "Remove";
+        remove.addEventListener("click", () => {
+            backend.RemovePersonalWord(word).then(refreshPersonalWords).catch(showSettingsError);
+        });
+        item.append(remove);
+        list.append(item);
+    }
+}
+
+document.getElementById("open-settings").addEventListener("click", () => {
+    openSettings().catch(console.error);
+});
+
+document.getElementById("close-settings").addEventListener("click", closeSettings);
+
+document.getElementById("add-personal").addEventListener("click", () => {
+    const input = document.getElementById("new-word");
+    const word = input.value.trim();
+    if (!word) {
+        return;
+    }
+    backend.AddToDictionary(word)
+        .then(() => {
+            input.value = "";
+            return refreshPersonalWords();
+        })
+        .catch(showSettingsError);
+});
+
+settingsForm.addEventListener("submit", (event) => {
+    event.preventDefault();
+
+    const form = settingsForm.elements;
+    const detected = [...document.querySelectorAll("#detect-languages input:checked")]
+        .map((box) => box.value);
+
+    const config = {
+        ...editedConfig,
+        correction_mode: form.correction_mode.value,
+        language: form.language.value,
+        detect_languages: detected,
+        min_score: parseFloat(form.min_score.value),
+        max_suggestions: parseInt(form.max_suggestions.value, 10),
+        word_timeout: form.word_timeout.value.trim(),
+        correction_delay: form.correction_delay.value.trim(),
+        undo_hotkey: form.undo_hotkey.value.trim(),
+        confirm_hotkey: form.confirm_hotkey.value.trim(),
+        pick_modifiers: form.pick_modifiers.value.trim(),
+        pause_hotkey: form.pause_hotkey.value.trim(),
+        secure_guard: form.secure_guard.checked,
+        grammar: form.grammar.checked,
+        keyboard_layout: form.keyboard_layout.value,
+    };
+
+    backend.SetConfig(config)
+        .then(() => {
+            editedConfig = config;
+            closeSettings();
+        })
+        .catch(showSettingsError);
 });
 
 // Signal that frontend is ready
@@ -3,58 +3,104 @@ package app
 import (
 	"context"
 	"fmt"
-	"log"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
+	"unicode/utf8"
 
 	"github.com/axide-dev/axidev-corrige/internal/checker"
 	"github.com/axide-dev/axidev-corrige/internal/display"
+	"github.com/axide-dev/axidev-corrige/internal/focus"
 	"github.com/axide-dev/axidev-corrige/internal/input"
+	"github.com/axide-dev/axidev-corrige/internal/layout"
+	"github.com/axide-dev/axidev-corrige/internal/logging"
+	"github.com/axide-dev/axidev-corrige/internal/ngram"
+	"github.com/axide-dev/axidev-corrige/internal/secure"
+	"github.com/axide-dev/axidev-corrige/internal/server"
 	"github.com/axide-dev/axidev-corrige/internal/state"
+	"github.com/axide-dev/axidev-corrige/internal/tokenize"
 	"github.com/axide-dev/axidev-corrige/internal/writing"
 
 	"github.com/axide-dev/axidev-io-go/keyboard"
 	"github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
-// Config holds application configuration
-type Config struct {
-	WordTimeout time.Duration
+// correctionRecord remembers the last auto-correction so it can be undone
+type correctionRecord struct {
+	Original   string
+	Correction string
+	Separator  string
 }
 
-// DefaultConfig returns default configuration
-func DefaultConfig() Config {
-	return Config{
-		WordTimeout: 5 * time.Second,
-	}
+// settings is the state derived from the configuration, swapped as a
+// whole when the configuration is reloaded
+type settings struct {
+	config        Config
+	detector      *checker.Detector
+	personal      *checker.PersonalDictionary
+	undoHotkey    input.Hotkey
+	confirmHotkey input.Hotkey
+	pauseHotkey   input.Hotkey
+	pickHotkeys   []input.Hotkey
 }
 
 // App is the main application orchestrator
 type App struct {
-	ctx     context.Context
-	config  Config
-	state   *state.Machine
-	writing *writing.Writing
-	checker *checker.Checker
-	input   *input.Handler
-	display *display.Manager
-}
-
-// New creates a new App instance
-func New(cfg Config) (*App, error) {
-	// Initialize the spell checker
-	chk, err := checker.NewFrenchChecker()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create checker: %w", err)
-	}
-
-	fmt.Printf("Loaded %d French words into dictionary\n", chk.WordCount())
+	ctx      context.Context
+	settings atomic.Pointer[settings]
+	state    *state.Machine
+	writing  *writing.Writing
+	input    *input.Handler
+	display  *display.Manager
+	focus    focus.Provider
+	log      *logging.Logger
+
+	// configPath is the watched config file, empty if not file-backed
+	configPath string
+	stopWatch  context.CancelFunc
+	// configMu serialises changes to settings
+	configMu sync.Mutex
+
+	// server is the running API server, nil when disabled
+	server   *server.Server
+	serverMu sync.Mutex
+
+	mu sync.Mutex
+	// flagged is the last completed word reported as misspelled
+	flagged *flaggedWord
+	// grammarNote is the grammar issue ending at the last completed word
+	grammarNote *grammarNote
+	// lastCorrection is the correction that can still be undone, nil once
+	// the user typed anything else
+	lastCorrection *correctionRecord
+	// window is the last focused window and appAction its app_rules action
+	window    focus.Window
+	appAction focus.Action
+	// pausedBy is what paused tracking, and securePause the details when
+	// it was the secure input guard
+	pausedBy    pauseSource
+	securePause *securePause
+	// keyRun is the raw keystroke run followed by the secure input guard
+	keyRun secure.Run
+}
 
+// New creates a new App instance logging to logger, or nowhere if nil
+func New(cfg Config, logger *logging.Logger) (*App, error) {
+	if logger == nil {
+		logger = logging.Discard()
+	}
 	app := &App{
-		config:  cfg,
+		log:     logger,
 		state:   state.NewMachine(),
 		writing: writing.NewWriting(writing.Config{Timeout: cfg.WordTimeout}),
-		checker: chk,
 		display: display.NewManager(),
+		focus:   focus.Cached(focus.NewProvider(), focusRefresh),
+	}
+
+	if err := app.applyConfig(cfg); err != nil {
+		return nil, err
 	}
 
 	// Register state transition handler
@@ -63,9 +109,165 @@ func New(cfg Config) (*App, error) {
 	return app, nil
 }
 
+// UseConfigFile makes the app persist settings changes to path and reload
+// them when the file is edited
+func (a *App) UseConfigFile(path string) {
+	a.configPath = path
+}
+
+// current returns the active configuration-derived state
+func (a *App) current() *settings {
+	return a.settings.Load()
+}
+
+// applyConfig validates cfg and swaps in the state derived from it,
+// reloading dictionaries only when the settings of the checkers changed
+func (a *App) applyConfig(cfg Config) error {
+	a.configMu.Lock()
+	defer a.configMu.Unlock()
+	return a.applyConfigLocked(cfg)
+}
+
+// applyConfigLocked is applyConfig for callers holding configMu
+func (a *App) applyConfigLocked(cfg Config) error {
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid config:\n%w", err)
+	}
+
+	undoHotkey, err := input.ParseHotkey(cfg.UndoHotkey)
+	if err != nil {
+		return err
+	}
+	confirmHotkey, err := input.ParseHotkey(cfg.ConfirmHotkey)
+	if err != nil {
+		return err
+	}
+	pauseHotkey, err := input.ParseHotkey(cfg.PauseHotkey)
+	if err != nil {
+		return err
+	}
+	pickModifiers, err := input.ParseModifiers(cfg.PickModifiers)
+	if err != nil {
+		return err
+	}
+
+	prev := a.current()
+	next := &settings{
+		config:        cfg,
+		undoHotkey:    undoHotkey,
+		confirmHotkey: confirmHotkey,
+		pauseHotkey:   pauseHotkey,
+		pickHotkeys:   input.DigitHotkeys(pickModifiers, cfg.MaxSuggestions),
+	}
+
+	if prev != nil && prev.config.PersonalDictionary == cfg.PersonalDictionary {
+		next.personal = prev.personal
+	} else if next.personal, err = loadPersonal(cfg.PersonalDictionary); err != nil {
+		return err
+	}
+
+	if prev != nil && prev.personal == next.personal && sameCheckers(prev.config, cfg) {
+		next.detector = prev.detector
+	} else if next.detector, err = a.loadDetector(cfg, next.personal); err != nil {
+		return err
+	}
+
+	level, err := logging.ParseLevel(cfg.LogLevel)
+	if err != nil {
+		return err
+	}
+	a.log.SetLevel(level)
+	a.log.SetRedact(cfg.LogRedact)
+
+	a.settings.Store(next)
+	a.writing.SetTimeout(cfg.WordTimeout)
+
+	if a.ctx != nil && prev != nil && prev.config.Window != cfg.Window {
+		runtime.WindowSetSize(a.ctx, cfg.Window.Width, cfg.Window.Height)
+	}
+	if a.ctx != nil && prev != nil && prev.config.Server != cfg.Server {
+		go a.restartServer(cfg.Server)
+	}
+	return nil
+}
+
+// loadPersonal opens the personal dictionary at path, or at the default
+// location if path is empty
+func loadPersonal(path string) (*checker.PersonalDictionary, error) {
+	if path == "" {
+		var err error
+		path, err = checker.DefaultPersonalDictionaryPath()
+		if err != nil {
+			return nil, fmt.Errorf("failed to locate personal dictionary: %w", err)
+		}
+	}
+	personal, err := checker.LoadPersonalDictionary(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load personal dictionary: %w", err)
+	}
+	return personal, nil
+}
+
+// loadDetector loads the checkers for the configured languages
+func (a *App) loadDetector(cfg Config, personal *checker.PersonalDictionary) (*checker.Detector, error) {
+	codes := []string{cfg.Language}
+	for _, code := range cfg.DetectLanguages {
+		if code != cfg.Language {
+			codes = append(codes, code)
+		}
+	}
+
+	var keys *layout.Layout
+	if cfg.KeyboardLayout != "" {
+		var err error
+		if keys, err = layout.Load(cfg.KeyboardLayout); err != nil {
+			return nil, err
+		}
+		a.log.Info("Keyboard layout loaded", "layout", keys.Name)
+	}
+
+	checkers := make([]*checker.Checker, 0, len(codes))
+	for _, code := range codes {
+		chk, err := checker.NewChecker(code)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %s checker: %w", code, err)
+		}
+		a.log.Info("Dictionary loaded", "language", chk.Language().Code, "words", chk.WordCount())
+		chk.SetLogger(a.log.Logger)
+		chk.SetPersonal(personal)
+		chk.SetLayout(keys)
+		checkers = append(checkers, chk)
+	}
+
+	if cfg.NgramModel != "" {
+		model, err := ngram.Load(cfg.NgramModel)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load n-gram model: %w", err)
+		}
+		a.log.Info("N-gram model loaded", "language", cfg.Language, "ngrams", model.Len())
+		checkers[0].SetRanker(model)
+	}
+	return checker.NewDetector(checkers, checker.DefaultDetectionWindow), nil
+}
+
+// sameCheckers returns true if both configs load the same dictionaries,
+// n-gram model and keyboard layout
+func sameCheckers(a, b Config) bool {
+	return a.Language == b.Language && slices.Equal(a.DetectLanguages, b.DetectLanguages) &&
+		a.NgramModel == b.NgramModel && a.KeyboardLayout == b.KeyboardLayout
+}
+
+// setContext records the Wails context, read by applyConfig from the
+// config watcher
+func (a *App) setContext(ctx context.Context) {
+	a.configMu.Lock()
+	defer a.configMu.Unlock()
+	a.ctx = ctx
+}
+
 // Startup is called when the Wails app starts
 func (a *App) Startup(ctx context.Context) {
-	a.ctx = ctx
+	a.setContext(ctx)
 
 	// Set window always on top
 	runtime.WindowSetAlwaysOnTop(ctx, true)
@@ -73,12 +275,27 @@ func (a *App) Startup(ctx context.Context) {
 	// Start display manager
 	a.display.Start(ctx)
 
+	if _, err := a.focus.Focused(); err != nil {
+		a.log.Warn("app_rules are not applied", "err", err)
+	}
+
+	// Serve the spell-check API if enabled
+	a.restartServer(a.current().config.Server)
+
+	// Reload the configuration when the file changes
+	if a.configPath != "" {
+		watchCtx, cancel := context.WithCancel(ctx)
+		a.stopWatch = cancel
+		go a.watchConfig(watchCtx, a.configPath)
+	}
+
 	// Initialize input handler
 	handler, err := input.NewHandler(input.Config{
 		OnEvent: a.handleKeyEvent,
+		Logger:  a.log.Logger,
 	})
 	if err != nil {
-		log.Printf("Failed to create input handler: %v", err)
+		a.log.Error("Failed to create input handler", "err", err)
 		return
 	}
 	a.input = handler
@@ -86,17 +303,19 @@ func (a *App) Startup(ctx context.Context) {
 	// Request permissions if needed
 	if handler.NeedsPermissions() {
 		if !handler.RequestPermissions() {
-			fmt.Println("Warning: Permissions not granted, auto-correction may not work")
+			a.log.Warn("Permissions not granted, auto-correction may not work")
 		}
 	}
 
 	// Start keyboard listener
 	go func() {
 		if err := a.input.Start(); err != nil {
-			log.Printf("Input handler error: %v", err)
+			a.log.Error("Input handler stopped", "err", err)
 		}
 	}()
 
+	a.log.Info("Listening for keyboard events")
+
 	// Transition to idle state
 	a.state.Transition(state.Idle)
 	a.updateDisplay()
@@ -104,6 +323,10 @@ func (a *App) Startup(ctx context.Context) {
 
 // Shutdown is called when the app closes
 func (a *App) Shutdown(ctx context.Context) {
+	if a.stopWatch != nil {
+		a.stopWatch()
+	}
+	a.restartServer(ServerConfig{})
 	a.display.Stop()
 	if a.input != nil {
 		a.input.Close()
@@ -117,22 +340,84 @@ func (a *App) handleKeyEvent(event keyboard.KeyEvent) {
 		return
 	}
 
+	// The pause hotkey is the only key handled while paused
+	current := a.current()
+	if current.pauseHotkey.Matches(event) {
+		a.TogglePause()
+		return
+	}
+
+	// Keys typed into secure fields are dropped before anything else
+	if a.guardSecureInput(event) {
+		return
+	}
+
 	// Check if we can accept input
 	if !a.state.CanAcceptInput() {
 		return
 	}
 
+	// Modifier presses alone never change the tracked text
+	if input.IsModifierKey(event) {
+		return
+	}
+
+	// Input in ignored applications is not tracked at all
+	if a.checkFocus() == focus.ActionIgnore {
+		return
+	}
+	mode := a.mode()
+
+	// Undo the last correction with the chord, or with Backspace pressed
+	// right after it
+	if current.undoHotkey.Matches(event) {
+		a.undoCorrection(false)
+		return
+	}
+
+	// Accept the best or a numbered suggestion for the word just flagged
+	if current.confirmHotkey.Matches(event) {
+		if mode.CanReplace() {
+			a.replaceFlagged(0)
+		}
+		return
+	}
+	for i, hotkey := range current.pickHotkeys {
+		if hotkey.Matches(event) {
+			if mode.CanReplace() {
+				a.replaceFlagged(i)
+			}
+			return
+		}
+	}
+	if input.IsBackspace(event) && a.undoCorrection(true) {
+		return
+	}
+	a.forgetPending()
+
 	// Check for timeout
 	if a.writing.CheckTimeout() {
-		fmt.Println("Timeout reached, cleared writing buffer")
+		a.log.Debug("Timeout reached, cleared writing buffer")
 		a.state.Transition(state.Idle)
 	}
 
+	// Handle cursor movement and deletions
+	if action := input.EditActionFor(event); action != input.EditNone {
+		a.editKeyRun(action)
+		a.handleEdit(action)
+		return
+	}
+
 	r := event.Rune()
 
+	// Password-like runs and words split by punctuation are not tracked
+	if a.guardKeyRun(r) {
+		return
+	}
+
 	// Handle word separators
 	if input.IsWordSeparator(r) {
-		a.handleWordComplete()
+		a.handleWordComplete(string(r))
 		return
 	}
 
@@ -152,87 +437,246 @@ func (a *App) handleCharacter(r rune) {
 	a.writing.AddChar(r)
 	a.updateDisplay()
 
-	fmt.Printf("Added char '%c', current word: %s\n", r, a.writing.GetCurrentWord().Text)
+	a.log.Debug("Added char", logging.Secret("word", a.writing.GetCurrentWord().Text))
+}
+
+// handleEdit applies a cursor or deletion key to the writing buffer
+func (a *App) handleEdit(action input.EditAction) {
+	var tracking bool
+	switch action {
+	case input.EditBackspace:
+		tracking = a.writing.DeleteBackward()
+	case input.EditDeleteWordBackward:
+		tracking = a.writing.DeleteWordBackward()
+	case input.EditDelete:
+		tracking = a.writing.DeleteForward()
+	case input.EditLeft:
+		tracking = a.writing.MoveLeft()
+	case input.EditRight:
+		tracking = a.writing.MoveRight()
+	case input.EditWordLeft:
+		tracking = a.writing.MoveWordLeft()
+	case input.EditWordRight:
+		tracking = a.writing.MoveWordRight()
+	default:
+		// Home, End and other jumps land in text the buffer does not know
+		a.writing.Clear()
+	}
+
+	if !tracking {
+		a.log.Debug("Cursor left tracked text, cleared writing buffer", "action", action.String())
+	}
+
+	if a.writing.IsEmpty() {
+		a.state.Transition(state.Idle)
+	} else if a.state.Is(state.Idle) {
+		a.state.Transition(state.Listening)
+	}
+	a.updateDisplay()
 }
 
 // handleWordComplete processes word completion
-func (a *App) handleWordComplete() {
-	word := a.writing.CompleteWord()
+func (a *App) handleWordComplete(separator string) {
+	word := a.writing.CompleteWord(separator)
 	if word == nil {
 		return
 	}
 
-	fmt.Printf("\n=== Word completed: %s ===\n", word.Text)
+	log := a.log.With(logging.Secret("word", word.Text))
+	log.Debug("Word completed")
+
+	// Only the word itself is checked, elisions and trailing hyphens are kept
+	parts := tokenize.Split(word.Text)
+	if !tokenize.Checkable(parts.Word) {
+		log.Debug("Spelling skipped, not a word")
+		a.wordDone()
+		return
+	}
+
+	// Check spelling against the language being typed
+	cfg := a.current().config
+	mode := a.mode()
+	if mode == ModeOff {
+		log.Debug("Spelling skipped, correction is off")
+		a.wordDone()
+		return
+	}
 
-	// Check spelling
-	result := a.checker.Check(word.Text, 3)
+	chk := a.checkerFor(parts.Word, true)
+	log = log.With("language", chk.Language().Code)
+	result := chk.CheckContext(parts.Word, a.sentenceContext(), cfg.MaxSuggestions)
 
 	if result.IsCorrect {
-		fmt.Println("Spelling: ✓ CORRECT")
+		log.Debug("Spelling correct")
 	} else {
-		fmt.Println("Spelling: ✗ INCORRECT")
-
-		if len(result.Suggestions) > 0 {
-			words := make([]string, len(result.Suggestions))
-			score := make([]float64, len(result.Suggestions))
-			for i, s := range result.Suggestions {
-				words[i] = s.Value
-				score[i] = s.Score
-			}
-			fmt.Printf("Suggestions: %v\n", words)
-			fmt.Printf("Scores: %v\n", score)
-
-			// Check score of best suggestion
-			if result.Suggestions[0].Score < 0.8 {
-				fmt.Println("Best suggestion score too low, skipping auto-correction")
-				fmt.Println()
-				a.updateDisplay()
-				return
-			}
-
-			// Perform auto-correction
-			if a.input != nil && a.input.CanSend() {
-				correction := result.Suggestions[0].Value
-				a.performCorrection(word.Text, correction)
+		a.setFlagged(&flaggedWord{
+			Original:    word.Text,
+			Token:       word.Text,
+			Parts:       parts,
+			Separator:   separator,
+			Suggestions: result.Suggestions,
+			Replaceable: true,
+		})
+
+		if len(result.Suggestions) == 0 {
+			log.Debug("Spelling incorrect, no suggestion")
+		} else {
+			log.Debug("Spelling incorrect",
+				logging.Secret("best", result.Suggestions[0].Value),
+				"score", result.Suggestions[0].Score,
+				"suggestions", len(result.Suggestions))
+
+			_, confident := result.AutoCorrection(cfg.MinScore)
+			switch {
+			case mode != ModeAuto:
+				log.Debug("Not replacing", "mode", mode)
+			case !confident:
+				log.Debug("Best suggestion score too low, skipping auto-correction")
+			default:
+				// Perform auto-correction
+				a.replaceFlagged(0)
 			}
 		}
 	}
-	fmt.Println()
 
-	// Update display
-	a.updateDisplay()
+	// Grammar is checked on the sentence once the word is final
+	a.checkGrammar(chk)
+
+	a.wordDone()
+}
 
-	// Transition back to idle if writing buffer is empty
+// wordDone updates the display once a completed word was handled, checked
+// or not, and goes back to idle if the writing buffer is empty
+func (a *App) wordDone() {
+	a.updateDisplay()
 	if a.writing.IsEmpty() {
 		a.state.Transition(state.Idle)
 	}
 }
 
-// performCorrection corrects a misspelled word
-func (a *App) performCorrection(original, correction string) {
-	fmt.Printf("Auto-correcting '%s' to '%s'\n", original, correction)
+// checkerFor returns the checker for the language of word, detected from
+// the words completed before it. A completed word is the last one of the
+// buffer and is left out so it does not vote for its own language.
+func (a *App) checkerFor(word string, completed bool) *checker.Checker {
+	words := a.writing.GetWords()
+	if completed && len(words) > 0 {
+		words = words[:len(words)-1]
+	}
+	context := make([]string, len(words))
+	for i, w := range words {
+		context[i] = tokenize.Split(w.Text).Word
+	}
+	return a.current().detector.CheckerFor(word, context)
+}
+
+// sentenceContext returns the words typed right before the last completed
+// one, back to the last punctuation, for ranking its suggestions
+func (a *App) sentenceContext() []string {
+	words := a.writing.GetWords()
+	if len(words) == 0 {
+		return nil
+	}
+	words = words[:len(words)-1]
+
+	start := len(words)
+	for start > 0 && strings.TrimSpace(words[start-1].Separator) == "" {
+		start--
+	}
+	context := make([]string, 0, len(words)-start)
+	for _, w := range words[start:] {
+		context = append(context, tokenize.Split(w.Text).Word)
+	}
+	return context
+}
+
+// performCorrection replaces original, the text typed before separator,
+// with correction
+func (a *App) performCorrection(original, correction, separator string) {
+	a.log.Info("Correcting word", logging.Secret("word", original), logging.Secret("correction", correction))
 
 	// Transition to correcting state
 	a.state.Transition(state.Correcting)
 	a.display.Correcting()
 
 	// Perform the correction
-	if err := a.input.ReplaceWord(correction); err != nil {
-		fmt.Printf("Correction failed: %v\n", err)
+	if err := a.input.ReplaceWord(original, correction, separator); err != nil {
+		a.log.Error("Correction failed", "err", err)
 	}
 
 	// Update the word in writing buffer
 	a.writing.ReplaceLastWord(correction)
 
-	// Delay before transitioning back
-	time.AfterFunc(input.CorrectionDelay(), func() {
+	a.finishCorrection("Auto-correction")
+}
+
+// undoCorrection restores the word replaced by the last correction. After
+// Backspace the trailing separator is already gone and the restored word
+// becomes the word being typed again. The original is learned so it is not
+// corrected next time. Returns false if there is nothing to undo.
+func (a *App) undoCorrection(afterBackspace bool) bool {
+	a.mu.Lock()
+	rec := a.lastCorrection
+	a.lastCorrection = nil
+	a.mu.Unlock()
+
+	if rec == nil || a.input == nil || !a.input.CanSend() {
+		return false
+	}
+
+	a.log.Info("Undoing correction", logging.Secret("correction", rec.Correction), logging.Secret("word", rec.Original))
+
+	a.state.Transition(state.Correcting)
+	a.display.Correcting()
+
+	count := utf8.RuneCountInString(rec.Correction)
+	text := rec.Original
+	if !afterBackspace {
+		count += utf8.RuneCountInString(rec.Separator)
+		text += rec.Separator
+	}
+	if err := a.input.Retype(count, text); err != nil {
+		a.log.Error("Undo failed", "err", err)
+	}
+
+	if afterBackspace {
+		a.writing.RemoveLastWord()
+		a.writing.SetCurrentWord(rec.Original)
+	} else {
+		a.writing.ReplaceLastWord(rec.Original)
+	}
+
+	if err := a.current().personal.AddLearned(tokenize.Split(rec.Original).Word); err != nil {
+		a.log.Error("Failed to save personal dictionary", "err", err)
+	}
+	a.setFlagged(nil)
+
+	a.finishCorrection("Undo")
+	return true
+}
+
+// forgetPending drops the undoable correction and makes the flagged word
+// no longer replaceable once other keys are typed
+func (a *App) forgetPending() {
+	a.mu.Lock()
+	a.lastCorrection = nil
+	if a.flagged != nil {
+		a.flagged.Replaceable = false
+	}
+	a.mu.Unlock()
+}
+
+// finishCorrection leaves the correcting state once the injected keys have
+// been delivered, so they are not tracked as user input
+func (a *App) finishCorrection(label string) {
+	time.AfterFunc(a.current().config.CorrectionDelay, func() {
+		// Leave the state alone if the app was paused meanwhile
 		if a.writing.IsEmpty() {
-			a.state.Transition(state.Idle)
+			a.state.TransitionIf(state.Correcting, state.Idle)
 		} else {
-			a.state.Transition(state.Listening)
+			a.state.TransitionIf(state.Correcting, state.Listening)
 		}
 		a.updateDisplay()
-		fmt.Println("--- Auto-correction finished ---")
+		a.log.Debug(label + " finished")
 	})
 }
 
@@ -240,6 +684,12 @@ func (a *App) performCorrection(original, correction string) {
 func (a *App) updateDisplay() {
 	var text string
 	var displayState string
+	var flagged string
+	var hint string
+	var suggestions []string
+
+	cfg := a.current().config
+	mode := a.mode()
 
 	switch a.state.Current() {
 	case state.Correcting:
@@ -249,12 +699,49 @@ func (a *App) updateDisplay() {
 	case state.Idle:
 		text = "Waiting..."
 		displayState = display.StateWaiting
+		if app := a.ignoredApp(); app != "" {
+			text = "Ignoring " + app
+		}
 
 	case state.Listening:
 		word := a.writing.GetCurrentWord()
-		if word.IsEmpty() {
+		if mode == ModeOff {
+			text = word.Text
+			displayState = display.StateListening
+			if word.IsEmpty() {
+				text = "Correction off"
+				displayState = display.StateWaiting
+			}
+		} else if word.IsEmpty() {
 			// Show last completed word if any
-			if last := a.writing.GetLastWord(); last != nil {
+			last := a.writing.GetLastWord()
+			f := a.getFlagged()
+			if last != nil && f != nil && last.Text == f.Token {
+				flagged = f.Parts.Word
+				if f.Corrected() {
+					text = fmt.Sprintf("%s → %s", f.Original, f.Token)
+					displayState = display.StateCorrect
+				} else if len(f.Suggestions) > 0 {
+					text = fmt.Sprintf("%s → %s", f.Token, f.Parts.Join(f.Suggestions[0].Value))
+					displayState = display.StateSuggestion
+				}
+				if len(f.Suggestions) > 0 {
+					suggestions = make([]string, len(f.Suggestions))
+					for i, s := range f.Suggestions {
+						suggestions[i] = f.Parts.Join(s.Value)
+					}
+					if f.Replaceable && mode.CanReplace() {
+						hint = a.pickHint()
+					}
+				} else {
+					text = last.Text + " ?"
+					displayState = display.StateIncorrect
+				}
+			} else if g := a.getGrammarNote(); last != nil && g != nil && last.Text == g.Last {
+				text = fmt.Sprintf("%s → %s", g.Before, g.After)
+				displayState = display.StateGrammar
+				hint = g.Message
+			} else if last != nil {
 				text = last.Text + " ✓"
 				displayState = display.StateCorrect
 			} else {
@@ -262,12 +749,13 @@ func (a *App) updateDisplay() {
 				displayState = display.StateListening
 			}
 		} else {
-			result := a.checker.Check(word.Text, 1)
-			if result.IsCorrect {
+			parts := tokenize.Split(word.Text)
+			result := a.checkerFor(parts.Word, false).Check(parts.Word, 1)
+			if result.IsCorrect || !tokenize.Checkable(parts.Word) {
 				text = word.Text + " ✓"
 				displayState = display.StateCorrect
 			} else if len(result.Suggestions) > 0 {
-				text = fmt.Sprintf("%s → %s", word.Text, result.Suggestions[0].Value)
+				text = fmt.Sprintf("%s → %s", word.Text, parts.Join(result.Suggestions[0].Value))
 				displayState = display.StateSuggestion
 			} else {
 				text = word.Text + " ?"
@@ -277,15 +765,107 @@ func (a *App) updateDisplay() {
 
 	case state.Paused:
 		text = "Paused"
-		displayState = display.StateWaiting
+		displayState = display.StatePaused
+		if reason := a.securePauseReason(); reason != secure.ReasonNone {
+			text = fmt.Sprintf("Paused (%s)", reason)
+			hint = "Resumes once the field is left"
+		} else if cfg.PauseHotkey != "" {
+			hint = cfg.PauseHotkey + " to resume"
+		}
 	}
 
-	a.display.Send(text, displayState)
+	a.display.SendUpdate(display.Update{
+		Text:        text,
+		State:       displayState,
+		Word:        flagged,
+		Hint:        hint,
+		Suggestions: suggestions,
+	})
+}
+
+// pickHint describes the hotkeys that replace the flagged word
+func (a *App) pickHint() string {
+	cfg := a.current().config
+	var hints []string
+	if keys := pickKeys(cfg.MaxSuggestions); cfg.PickModifiers != "" && keys != "" {
+		hints = append(hints, cfg.PickModifiers+"+"+keys+" to pick")
+	}
+	if cfg.ConfirmHotkey != "" {
+		hints = append(hints, cfg.ConfirmHotkey+" to correct")
+	}
+	return strings.Join(hints, " · ")
+}
+
+// pickKeys returns the digit keys picking one of count suggestions, like
+// "1…3", as bound by input.DigitHotkeys
+func pickKeys(count int) string {
+	count = min(count, 9)
+	switch {
+	case count < 1:
+		return ""
+	case count == 1:
+		return "1"
+	default:
+		return fmt.Sprintf("1…%d", count)
+	}
 }
 
 // onStateTransition handles state change events
 func (a *App) onStateTransition(from, to state.State) {
-	fmt.Printf("State: %s → %s\n", from, to)
+	a.log.Debug("State changed", "from", from.String(), "to", to.String())
+}
+
+// AddToDictionary teaches a word to every loaded checker and persists it
+// in the personal dictionary (for UI binding)
+func (a *App) AddToDictionary(word string) error {
+	word = strings.TrimSpace(word)
+	if word == "" {
+		return fmt.Errorf("no word to add")
+	}
+
+	current := a.current()
+	if err := current.personal.Add(word); err != nil {
+		return fmt.Errorf("failed to save personal dictionary: %w", err)
+	}
+	for _, chk := range current.detector.Checkers() {
+		chk.Learn(word)
+	}
+	a.log.Info("Added to personal dictionary", logging.Secret("word", word))
+
+	a.clearFlagged(word)
+	return nil
+}
+
+// IgnoreWord stops flagging a word without offering it as a suggestion and
+// persists it in the personal dictionary (for UI binding)
+func (a *App) IgnoreWord(word string) error {
+	word = strings.TrimSpace(word)
+	if word == "" {
+		return fmt.Errorf("no word to ignore")
+	}
+
+	if err := a.current().personal.Ignore(word); err != nil {
+		return fmt.Errorf("failed to save personal dictionary: %w", err)
+	}
+	a.log.Info("Ignoring word", logging.Secret("word", word))
+
+	a.clearFlagged(word)
+	return nil
+}
+
+// GetLastFlagged returns the last word reported as misspelled (for UI binding)
+func (a *App) GetLastFlagged() string {
+	return a.getLastFlagged()
+}
+
+// clearFlagged forgets the flagged word once it has been accepted
+func (a *App) clearFlagged(word string) {
+	a.mu.Lock()
+	if a.flagged != nil && strings.EqualFold(a.flagged.Parts.Word, word) {
+		a.flagged = nil
+	}
+	a.mu.Unlock()
+	a.updateDisplay()
 }
 
 // GetState returns the current application state (for UI binding)
@@ -0,0 +1,77 @@
+package app
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/axide-dev/axidev-corrige/internal/focus"
+)
+
+// newTestApp returns an app with the default config, user directories in
+// a temporary directory and a fake focus provider
+func newTestApp(t *testing.T) (*App, Config) {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "config"))
+	t.Setenv("XDG_DATA_HOME", filepath.Join(home, "data"))
+
+	cfg := DefaultConfig()
+	cfg.PersonalDictionary = filepath.Join(home, "personal.json")
+	a, err := New(cfg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.UseFocusProvider(&focus.Fake{})
+	a.UseConfigFile(filepath.Join(home, "config.json"))
+	return a, cfg
+}
+
+// TestConcurrentReload applies configs from the settings view and the
+// file watcher while the app starts, to be run with -race
+func TestConcurrentReload(t *testing.T) {
+	a, cfg := newTestApp(t)
+	cfg.Server.Token = "secret"
+	if err := a.applyConfig(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	wg.Go(func() { a.setContext(context.Background()) })
+	for i := range 4 {
+		next := cfg
+		next.MinScore = 0.5 + float64(i)/10
+		wg.Go(func() {
+			if err := a.applyConfig(next); err != nil {
+				t.Error(err)
+			}
+		})
+		fromSettings := next
+		fromSettings.Server.Token = ""
+		wg.Go(func() {
+			if err := a.SetConfig(fromSettings); err != nil {
+				t.Error(err)
+			}
+		})
+		wg.Go(func() { _ = a.GetConfig() })
+	}
+	wg.Wait()
+
+	if got := a.current().config.Server.Token; got != "secret" {
+		t.Errorf("token = %q after reloads, want it kept", got)
+	}
+	if got := a.GetConfig().Server.Token; got != "" {
+		t.Errorf("GetConfig exposed the token %q", got)
+	}
+}
+
+func TestPickKeys(t *testing.T) {
+	tests := map[int]string{0: "", 1: "1", 3: "1…3", 9: "1…9", 12: "1…9"}
+	for count, want := range tests {
+		if got := pickKeys(count); got != want {
+			t.Errorf("pickKeys(%d) = %q, want %q", count, got, want)
+		}
+	}
+}
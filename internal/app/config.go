@@ -0,0 +1,322 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/axide-dev/axidev-corrige/internal/checker"
+	"github.com/axide-dev/axidev-corrige/internal/focus"
+	"github.com/axide-dev/axidev-corrige/internal/input"
+	"github.com/axide-dev/axidev-corrige/internal/layout"
+	"github.com/axide-dev/axidev-corrige/internal/logging"
+	"github.com/axide-dev/axidev-corrige/internal/paths"
+	"github.com/axide-dev/axidev-corrige/internal/server"
+)
+
+// Config holds application configuration. It is persisted as JSON in the
+// user config directory, durations written as strings such as "5s".
+type Config struct {
+	WordTimeout time.Duration `json:"word_timeout"`
+	// Language is the code of the dictionary to load (see checker.Languages)
+	Language string `json:"language"`
+	// DetectLanguages lists additional dictionaries to load. When set, the
+	// language of each word is detected among these and Language, which
+	// stays the fallback.
+	DetectLanguages []string `json:"detect_languages"`
+	// PersonalDictionary is the path of the user word list, empty for the
+	// default location in the user config directory
+	PersonalDictionary string `json:"personal_dictionary"`
+	// NgramModel is the path of an n-gram model file ranking suggestions
+	// for Language by the words before them, empty to disable
+	NgramModel string `json:"ngram_model"`
+	// KeyboardLayout is a built-in layout name (azerty, qwerty, qwertz,
+	// bepo) or the path of a layout description. Typos on neighbouring
+	// keys then rank first. Empty uses the plain edit distance.
+	KeyboardLayout string `json:"keyboard_layout"`
+	// UndoHotkey restores the word replaced by the last auto-correction,
+	// like pressing Backspace right after it. Empty disables the chord.
+	UndoHotkey string `json:"undo_hotkey"`
+	// MaxSuggestions is the number of suggestions requested per word
+	MaxSuggestions int `json:"max_suggestions"`
+	// MinScore is the score the best suggestion needs to be auto-applied
+	MinScore float64 `json:"min_score"`
+	// CorrectionDelay is how long injected keys are ignored after a
+	// correction
+	CorrectionDelay time.Duration `json:"correction_delay"`
+	// Mode selects what happens to misspelled words
+	Mode CorrectionMode `json:"correction_mode"`
+	// ConfirmHotkey replaces the last flagged word with its best suggestion
+	// in confirm mode, and in auto mode when the score was too low
+	ConfirmHotkey string `json:"confirm_hotkey"`
+	// PickModifiers are held with a digit to replace the flagged word with
+	// the numbered suggestion shown in the overlay. Empty disables picking.
+	PickModifiers string `json:"pick_modifiers"`
+	// PauseHotkey suspends and resumes all tracking and checking
+	PauseHotkey string `json:"pause_hotkey"`
+	// AppRules ignore input or limit it to suggestions in the applications
+	// they match, the first matching rule wins
+	AppRules []focus.Rule `json:"app_rules"`
+	// SecureGuard pauses tracking on windows whose title asks for a
+	// password and on password-like words. Secure fields reported by the
+	// platform always pause it.
+	SecureGuard bool `json:"secure_guard"`
+	// Grammar flags agreement mistakes and common confusions such as a/à
+	// in French sentences
+	Grammar bool `json:"grammar"`
+	// LogLevel is the minimum level logged: debug, info, warn or error
+	LogLevel string `json:"log_level"`
+	// LogRedact hides typed words in logs, keeping only their length
+	LogRedact bool `json:"log_redact"`
+	// LogFile also writes logs to a rotating file in the user data
+	// directory. Read at startup only.
+	LogFile bool `json:"log_file"`
+	// Server is the local spell-check API
+	Server ServerConfig `json:"server"`
+	// Window is the overlay size
+	Window WindowConfig `json:"window"`
+}
+
+// CorrectionMode selects what happens to misspelled words
+type CorrectionMode string
+
+const (
+	// ModeOff disables checking
+	ModeOff CorrectionMode = "off"
+	// ModeSuggest flags words and shows suggestions, never typing anything
+	ModeSuggest CorrectionMode = "suggest"
+	// ModeConfirm shows suggestions and replaces the word on ConfirmHotkey
+	ModeConfirm CorrectionMode = "confirm"
+	// ModeAuto replaces words whose best suggestion reaches MinScore
+	ModeAuto CorrectionMode = "auto"
+)
+
+// Valid returns true if the mode is one of the known modes
+func (m CorrectionMode) Valid() bool {
+	switch m {
+	case ModeOff, ModeSuggest, ModeConfirm, ModeAuto:
+		return true
+	default:
+		return false
+	}
+}
+
+// CanReplace returns true if the mode allows typing replacements
+func (m CorrectionMode) CanReplace() bool {
+	return m == ModeConfirm || m == ModeAuto
+}
+
+// WindowConfig holds the overlay window size in pixels
+type WindowConfig struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// DefaultConfig returns default configuration
+func DefaultConfig() Config {
+	return Config{
+		WordTimeout:     5 * time.Second,
+		Language:        "fr",
+		DetectLanguages: []string{},
+		UndoHotkey:      "Ctrl+Shift+Z",
+		MaxSuggestions:  3,
+		MinScore:        checker.DefaultMinScore,
+		CorrectionDelay: input.CorrectionDelay(),
+		Mode:            ModeAuto,
+		ConfirmHotkey:   "Ctrl+Shift+Return",
+		PickModifiers:   "Ctrl+Shift",
+		PauseHotkey:     "Ctrl+Shift+P",
+		AppRules:        focus.DefaultRules(),
+		SecureGuard:     true,
+		Grammar:         true,
+		LogLevel:        "info",
+		LogRedact:       true,
+		Server: ServerConfig{
+			Port: server.DefaultPort,
+		},
+		Window: WindowConfig{
+			Width:  400,
+			Height: 100,
+		},
+	}
+}
+
+// MarshalJSON writes durations in time.Duration string form
+func (c Config) MarshalJSON() ([]byte, error) {
+	type plain Config
+	return json.Marshal(struct {
+		plain
+		WordTimeout     string `json:"word_timeout"`
+		CorrectionDelay string `json:"correction_delay"`
+	}{
+		plain:           plain(c),
+		WordTimeout:     c.WordTimeout.String(),
+		CorrectionDelay: c.CorrectionDelay.String(),
+	})
+}
+
+// UnmarshalJSON reads a config over the current values, rejecting unknown
+// keys so typos are reported instead of silently ignored. The former
+// auto_correct switch is still read when correction_mode is absent.
+func (c *Config) UnmarshalJSON(data []byte) error {
+	type plain Config
+	aux := struct {
+		*plain
+		WordTimeout     string `json:"word_timeout"`
+		CorrectionDelay string `json:"correction_delay"`
+		AutoCorrect     *bool  `json:"auto_correct"`
+	}{
+		plain:           (*plain)(c),
+		WordTimeout:     c.WordTimeout.String(),
+		CorrectionDelay: c.CorrectionDelay.String(),
+	}
+
+	mode := c.Mode
+	c.Mode = ""
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&aux); err != nil {
+		return err
+	}
+
+	switch {
+	case c.Mode != "":
+	case aux.AutoCorrect == nil:
+		c.Mode = mode
+	case *aux.AutoCorrect:
+		c.Mode = ModeAuto
+	default:
+		c.Mode = ModeSuggest
+	}
+
+	var err error
+	if c.WordTimeout, err = time.ParseDuration(aux.WordTimeout); err != nil {
+		return fmt.Errorf("word_timeout: %w", err)
+	}
+	if c.CorrectionDelay, err = time.ParseDuration(aux.CorrectionDelay); err != nil {
+		return fmt.Errorf("correction_delay: %w", err)
+	}
+	return nil
+}
+
+// Validate reports every invalid setting
+func (c Config) Validate() error {
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	if c.WordTimeout <= 0 {
+		fail("word_timeout must be positive, got %s", c.WordTimeout)
+	}
+	if c.CorrectionDelay < 0 || c.CorrectionDelay > 5*time.Second {
+		fail("correction_delay must be between 0s and 5s, got %s", c.CorrectionDelay)
+	}
+	if c.MaxSuggestions < 1 || c.MaxSuggestions > 10 {
+		fail("max_suggestions must be between 1 and 10, got %d", c.MaxSuggestions)
+	}
+	if c.MinScore < 0 {
+		fail("min_score must not be negative, got %g", c.MinScore)
+	}
+	for _, code := range append([]string{c.Language}, c.DetectLanguages...) {
+		if lang, ok := checker.LookupLanguage(code); !ok {
+			fail("unknown language %q", code)
+		} else if !lang.Available() {
+			fail("no dictionary installed for language %q", code)
+		}
+	}
+	if c.KeyboardLayout != "" {
+		if _, err := layout.Load(c.KeyboardLayout); err != nil {
+			fail("keyboard_layout: %v", err)
+		}
+	}
+	if !c.Mode.Valid() {
+		fail("correction_mode must be one of off, suggest, confirm, auto, got %q", c.Mode)
+	}
+	if _, err := input.ParseHotkey(c.UndoHotkey); err != nil {
+		fail("undo_hotkey: %v", err)
+	}
+	if _, err := input.ParseHotkey(c.ConfirmHotkey); err != nil {
+		fail("confirm_hotkey: %v", err)
+	}
+	if _, err := input.ParseHotkey(c.PauseHotkey); err != nil {
+		fail("pause_hotkey: %v", err)
+	}
+	if _, err := input.ParseModifiers(c.PickModifiers); err != nil {
+		fail("pick_modifiers: %v", err)
+	}
+	if _, err := logging.ParseLevel(c.LogLevel); err != nil {
+		fail("log_level: %v", err)
+	}
+	for i, rule := range c.AppRules {
+		if err := rule.Validate(); err != nil {
+			fail("app_rules[%d]: %v", i, err)
+		}
+	}
+	if c.Server.Port < 1 || c.Server.Port > 65535 {
+		fail("server.port must be between 1 and 65535, got %d", c.Server.Port)
+	}
+	if c.Window.Width < 100 || c.Window.Height < 50 {
+		fail("window must be at least 100x50, got %dx%d", c.Window.Width, c.Window.Height)
+	}
+
+	return errors.Join(errs...)
+}
+
+// ConfigPath returns the config file location in the user config directory
+func ConfigPath() (string, error) {
+	dir, err := paths.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "config.json"), nil
+}
+
+// LoadConfig reads and validates the config file at path. Missing keys keep
+// their default value; a missing file is created with the defaults.
+func LoadConfig(path string) (Config, error) {
+	cfg := DefaultConfig()
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		if err := SaveConfig(path, cfg); err != nil {
+			return cfg, err
+		}
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, err
+	}
+
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return cfg, fmt.Errorf("invalid config %s:\n%w", path, err)
+	}
+	return cfg, nil
+}
+
+// SaveConfig writes the config to path, creating its directory if needed.
+// The file is readable by the user only as it may hold the API token.
+func SaveConfig(path string, cfg Config) error {
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	// WriteFile keeps the mode of an existing file, restrict it first
+	if err := os.Chmod(path, 0o600); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o600)
+}
@@ -0,0 +1,93 @@
+package app
+
+import (
+	"github.com/axide-dev/axidev-corrige/internal/checker"
+	"github.com/axide-dev/axidev-corrige/internal/tokenize"
+)
+
+// flaggedWord is the last completed word reported as misspelled. It stays
+// flagged after a replacement so another suggestion can be picked.
+type flaggedWord struct {
+	// Original is the word as typed, including elisions
+	Original string
+	// Token is the word currently on screen: Original or a replacement
+	Token string
+	// Parts splits Original into the checked word and kept elisions
+	Parts       tokenize.Parts
+	Separator   string
+	Suggestions []checker.Suggestion
+	// Replaceable is true while nothing was typed after the word, so the
+	// text before the cursor is still Token followed by Separator
+	Replaceable bool
+}
+
+// Corrected returns true if the word was replaced by a suggestion
+func (f *flaggedWord) Corrected() bool {
+	return f.Token != f.Original
+}
+
+// setFlagged records the last word reported as misspelled
+func (a *App) setFlagged(f *flaggedWord) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.flagged = f
+}
+
+// getFlagged returns a copy of the last flagged word, or nil
+func (a *App) getFlagged() *flaggedWord {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.flagged == nil {
+		return nil
+	}
+	f := *a.flagged
+	return &f
+}
+
+// getLastFlagged returns the last word reported as misspelled
+func (a *App) getLastFlagged() string {
+	if f := a.getFlagged(); f != nil {
+		return f.Parts.Word
+	}
+	return ""
+}
+
+// replaceFlagged replaces the last flagged word with one of its suggestions
+// if nothing was typed since, including when it was already replaced by
+// another suggestion. Returns false if it could not be replaced.
+func (a *App) replaceFlagged(index int) bool {
+	f := a.getFlagged()
+	switch {
+	case f == nil || !f.Replaceable:
+		a.log.Debug("No flagged word to replace")
+		return false
+	case index < 0 || index >= len(f.Suggestions):
+		a.log.Debug("No such suggestion", "index", index+1)
+		return false
+	case tokenize.IsLineBreak([]rune(f.Separator)[0]):
+		// Retyping across Enter or Tab could submit forms or move focus
+		a.log.Debug("Word ended by a line break, skipping correction")
+		return false
+	case a.input == nil || !a.input.CanSend():
+		return false
+	}
+
+	replacement := f.Parts.Join(f.Suggestions[index].Value)
+	if replacement == f.Token {
+		return true
+	}
+
+	a.performCorrection(f.Token, replacement, f.Separator)
+
+	a.mu.Lock()
+	if a.flagged != nil && a.flagged.Original == f.Original {
+		a.flagged.Token = replacement
+	}
+	a.lastCorrection = &correctionRecord{
+		Original:   f.Original,
+		Correction: replacement,
+		Separator:  f.Separator,
+	}
+	a.mu.Unlock()
+	return true
+}
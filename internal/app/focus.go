@@ -0,0 +1,81 @@
+package app
+
+import (
+	"time"
+
+	"github.com/axide-dev/axidev-corrige/internal/focus"
+	"github.com/axide-dev/axidev-corrige/internal/state"
+)
+
+// focusRefresh is how long the focused window is reused between key
+// presses before the window system is queried again
+const focusRefresh = 500 * time.Millisecond
+
+// UseFocusProvider replaces the source of the focused window, such as a
+// focus.Fake in tests
+func (a *App) UseFocusProvider(p focus.Provider) {
+	a.focus = p
+}
+
+// checkFocus follows the focused window and returns the app_rules action
+// for it. Switching applications drops the tracked text, which belongs to
+// the previous window; title changes alone keep it. When the window is
+// unknown the configured mode applies.
+func (a *App) checkFocus() focus.Action {
+	w, err := a.focus.Focused()
+	if err != nil {
+		w = focus.Window{}
+	}
+	action := focus.ActionFor(a.current().config.AppRules, w)
+
+	a.mu.Lock()
+	changed := !w.SameApp(a.window)
+	a.window = w
+	a.appAction = action
+	if changed {
+		a.flagged = nil
+		a.grammarNote = nil
+		a.lastCorrection = nil
+		a.keyRun.Reset()
+	}
+	a.mu.Unlock()
+
+	if changed {
+		a.log.Debug("Focus changed", "app", w.Name(), "action", action)
+		a.writing.Clear()
+		if a.state.Is(state.Listening) {
+			a.state.Transition(state.Idle)
+		}
+		a.updateDisplay()
+	}
+	return action
+}
+
+// mode returns the correction mode restricted by the rule of the focused
+// application
+func (a *App) mode() CorrectionMode {
+	mode := a.current().config.Mode
+
+	a.mu.Lock()
+	action := a.appAction
+	a.mu.Unlock()
+
+	switch {
+	case action == focus.ActionIgnore:
+		return ModeOff
+	case action == focus.ActionSuggest && mode.CanReplace():
+		return ModeSuggest
+	default:
+		return mode
+	}
+}
+
+// ignoredApp returns the focused application name if its input is ignored
+func (a *App) ignoredApp() string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.appAction != focus.ActionIgnore {
+		return ""
+	}
+	return a.window.Name()
+}
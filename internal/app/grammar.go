@@ -0,0 +1,58 @@
+package app
+
+import (
+	"github.com/axide-dev/axidev-corrige/internal/checker"
+	"github.com/axide-dev/axidev-corrige/internal/grammar"
+	"github.com/axide-dev/axidev-corrige/internal/logging"
+)
+
+// grammarNote is the last grammar issue found, shown while the last word
+// it covers is the last completed word
+type grammarNote struct {
+	grammar.Issue
+	// Before and After are the words of the issue as typed and rewritten
+	Before, After string
+	// Last is the last word of the issue as typed
+	Last string
+}
+
+// checkGrammar looks for a grammar issue ending with the word just
+// completed. The rules are French, other languages are not checked.
+func (a *App) checkGrammar(chk *checker.Checker) {
+	a.setGrammarNote(nil)
+	if !a.current().config.Grammar || chk.Language().Code != "fr" {
+		return
+	}
+
+	words := a.writing.GetWords()
+	issues := grammar.Check(words, chk)
+	for i := len(issues) - 1; i >= 0; i-- {
+		issue := issues[i]
+		if issue.End != len(words) {
+			continue
+		}
+		before, after := issue.Rewrite(words)
+		a.log.Debug("Grammar issue", "rule", issue.Rule, logging.Secret("text", before), logging.Secret("rewrite", after))
+		a.setGrammarNote(&grammarNote{
+			Issue:  issue,
+			Before: before,
+			After:  after,
+			Last:   words[len(words)-1].Text,
+		})
+		return
+	}
+}
+
+// setGrammarNote records the grammar issue shown in the overlay
+func (a *App) setGrammarNote(g *grammarNote) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.grammarNote = g
+}
+
+// getGrammarNote returns the grammar issue shown in the overlay, or nil
+func (a *App) getGrammarNote() *grammarNote {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.grammarNote
+}
@@ -0,0 +1,91 @@
+package app
+
+import (
+	"github.com/axide-dev/axidev-corrige/internal/state"
+)
+
+// pauseSource tells what paused tracking
+type pauseSource int
+
+const (
+	// pauseNone - tracking is not paused
+	pauseNone pauseSource = iota
+	// pauseManual - the user paused with the hotkey or the overlay
+	pauseManual
+	// pauseSecure - the secure input guard paused, and resumes by itself
+	pauseSecure
+)
+
+// Pause suspends tracking and checking, dropping everything typed so far
+// (for UI binding)
+func (a *App) Pause() {
+	if a.pause(pauseManual) {
+		a.log.Info("Paused")
+	}
+}
+
+// Resume restarts tracking after Pause, or after the secure input guard
+// paused it (for UI binding)
+func (a *App) Resume() {
+	if a.resume(pauseManual) {
+		a.log.Info("Resumed")
+	}
+}
+
+// pause drops the tracked text and enters the paused state. A manual pause
+// takes over a secure one, so leaving the secure field does not resume.
+// Returns false if tracking was already paused.
+func (a *App) pause(source pauseSource) bool {
+	a.writing.Clear()
+
+	a.mu.Lock()
+	a.flagged = nil
+	a.grammarNote = nil
+	a.lastCorrection = nil
+	a.keyRun.Reset()
+	if a.pausedBy != pauseManual {
+		a.pausedBy = source
+	}
+	if source == pauseManual {
+		a.securePause = nil
+	}
+	a.mu.Unlock()
+
+	paused := !a.state.Is(state.Paused)
+	a.state.Transition(state.Paused)
+	a.updateDisplay()
+	return paused
+}
+
+// resume leaves the paused state. Resuming for the secure input guard only
+// ends a pause it started. Returns false if nothing was resumed.
+func (a *App) resume(source pauseSource) bool {
+	a.mu.Lock()
+	if source != pauseManual && a.pausedBy != source {
+		a.mu.Unlock()
+		return false
+	}
+	a.pausedBy = pauseNone
+	a.securePause = nil
+	a.mu.Unlock()
+
+	resumed := a.state.TransitionIf(state.Paused, state.Idle)
+	a.updateDisplay()
+	return resumed
+}
+
+// TogglePause pauses or resumes and returns true if now paused (for UI
+// binding)
+func (a *App) TogglePause() bool {
+	if a.IsPaused() {
+		a.Resume()
+	} else {
+		a.Pause()
+	}
+	return a.IsPaused()
+}
+
+// IsPaused returns true if tracking is suspended (for UI binding)
+func (a *App) IsPaused() bool {
+	return a.state.Is(state.Paused)
+}
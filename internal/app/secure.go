@@ -0,0 +1,159 @@
+package app
+
+import (
+	"time"
+
+	"github.com/axide-dev/axidev-corrige/internal/focus"
+	"github.com/axide-dev/axidev-corrige/internal/input"
+	"github.com/axide-dev/axidev-corrige/internal/secure"
+	"github.com/axide-dev/axidev-corrige/internal/state"
+
+	"github.com/axide-dev/axidev-io-go/keyboard"
+)
+
+// securePause records why the secure input guard paused tracking
+type securePause struct {
+	reason secure.Reason
+	// window is the window focused when the pause started
+	window focus.Window
+	// lastKey is the time of the last key typed while paused
+	lastKey time.Time
+}
+
+// sensitiveContext returns why keys typed in window may be secret, or
+// secure.ReasonNone. Title heuristics are skipped when secure_guard is off.
+func (a *App) sensitiveContext(window focus.Window) secure.Reason {
+	switch {
+	case secure.InputEnabled():
+		return secure.ReasonSecureInput
+	case a.current().config.SecureGuard && secure.SensitiveTitle(window.Title):
+		return secure.ReasonWindowTitle
+	default:
+		return secure.ReasonNone
+	}
+}
+
+// guardSecureInput pauses tracking while keys go to a secure field and
+// resumes once it is left. Returns true if the key must not be tracked.
+func (a *App) guardSecureInput(event keyboard.KeyEvent) bool {
+	window, _ := a.focus.Focused()
+	reason := a.sensitiveContext(window)
+
+	var sp *securePause
+	a.mu.Lock()
+	if a.securePause != nil {
+		prev := *a.securePause
+		sp = &prev
+		a.securePause.lastKey = time.Now()
+	}
+	a.mu.Unlock()
+
+	switch {
+	case sp != nil:
+		if reason == secure.ReasonNone && a.secureFieldLeft(*sp, window, event) && a.resume(pauseSecure) {
+			a.log.Info("Secure input left, resumed")
+		}
+		// The key leaving the field still belongs to it
+		return true
+	case reason != secure.ReasonNone && !a.IsPaused():
+		a.pauseSecure(reason, window)
+		return true
+	default:
+		return false
+	}
+}
+
+// guardKeyRun follows the raw keystroke run since the last whitespace,
+// where punctuation cannot split a password like "hunter2.Blue!" into
+// harmless looking words. A run that looks like a password pauses
+// tracking; one with punctuation inside it is left untracked until the
+// next whitespace. Returns true if r must not be tracked.
+func (a *App) guardKeyRun(r rune) bool {
+	if !a.current().config.SecureGuard || (!input.IsPrintable(r) && !input.IsWordSeparator(r)) {
+		return false
+	}
+
+	a.mu.Lock()
+	a.keyRun.Add(r)
+	password, split := a.keyRun.LooksLikePassword(), a.keyRun.Split()
+	a.mu.Unlock()
+
+	switch {
+	case password:
+		window, _ := a.focus.Focused()
+		a.pauseSecure(secure.ReasonPasswordLike, window)
+		return true
+	case split:
+		a.dropKeyRun()
+		return true
+	default:
+		return false
+	}
+}
+
+// dropKeyRun forgets the words typed earlier in a split run, so none of
+// them is corrected or offered for correction
+func (a *App) dropKeyRun() {
+	a.mu.Lock()
+	dropped := a.flagged != nil || a.grammarNote != nil || a.lastCorrection != nil
+	a.flagged = nil
+	a.grammarNote = nil
+	a.lastCorrection = nil
+	a.mu.Unlock()
+
+	if !dropped && a.writing.IsEmpty() {
+		return
+	}
+	a.log.Debug("Punctuation inside a word, tracking stopped until the next space")
+	a.writing.Clear()
+	a.state.Transition(state.Idle)
+	a.updateDisplay()
+}
+
+// editKeyRun follows an edit key in the raw keystroke run: Backspace
+// removes the last key, anything else moves the caret out of the run
+func (a *App) editKeyRun(action input.EditAction) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if action == input.EditBackspace {
+		a.keyRun.Backspace()
+	} else {
+		a.keyRun.Reset()
+	}
+}
+
+// pauseSecure drops the buffer and pauses until the secure field is left.
+// Nothing about the typed text is logged.
+func (a *App) pauseSecure(reason secure.Reason, window focus.Window) {
+	a.mu.Lock()
+	a.securePause = &securePause{reason: reason, window: window, lastKey: time.Now()}
+	a.mu.Unlock()
+
+	if a.pause(pauseSecure) {
+		a.log.Info("Paused on secure input", "reason", reason)
+	}
+}
+
+// secureFieldLeft returns true once a password-like burst is over: the
+// field was submitted or left, focus moved to another application, or
+// typing stopped for the word timeout. Platform and title reasons end as
+// soon as they stop applying.
+func (a *App) secureFieldLeft(sp securePause, window focus.Window, event keyboard.KeyEvent) bool {
+	if sp.reason != secure.ReasonPasswordLike {
+		return true
+	}
+	return input.IsFieldExit(event) ||
+		!window.SameApp(sp.window) ||
+		time.Since(sp.lastKey) > a.current().config.WordTimeout
+}
+
+// securePauseReason returns why the secure input guard paused tracking, or
+// secure.ReasonNone
+func (a *App) securePauseReason() secure.Reason {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.securePause == nil {
+		return secure.ReasonNone
+	}
+	return a.securePause.reason
+}
@@ -0,0 +1,94 @@
+package app
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	"github.com/axide-dev/axidev-corrige/internal/checker"
+	"github.com/axide-dev/axidev-corrige/internal/paths"
+	"github.com/axide-dev/axidev-corrige/internal/server"
+)
+
+// ServerConfig configures the local spell-check API
+type ServerConfig struct {
+	Enabled bool `json:"enabled"`
+	// Port is the port listened on 127.0.0.1
+	Port int `json:"port"`
+	// Token is the Bearer token clients must send, empty to use the one
+	// generated in server.token in the user config directory
+	Token string `json:"token"`
+}
+
+// serverBackend exposes the app's dictionaries to the API server without
+// adding methods to the UI bindings
+type serverBackend struct {
+	app *App
+}
+
+func (b serverBackend) Detector() *checker.Detector {
+	return b.app.current().detector
+}
+
+func (b serverBackend) Personal() *checker.PersonalDictionary {
+	return b.app.current().personal
+}
+
+func (b serverBackend) AddWord(word string) error {
+	return b.app.AddToDictionary(word)
+}
+
+func (b serverBackend) IgnoreWord(word string) error {
+	return b.app.IgnoreWord(word)
+}
+
+func (b serverBackend) RemoveWord(word string) error {
+	return b.app.RemovePersonalWord(word)
+}
+
+// TokenPath returns the file holding the generated API token
+func TokenPath() (string, error) {
+	dir, err := paths.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "server.token"), nil
+}
+
+// restartServer stops the API server and starts it again if cfg enables
+// it. Failures are logged, the rest of the app keeps running.
+func (a *App) restartServer(cfg ServerConfig) {
+	a.serverMu.Lock()
+	defer a.serverMu.Unlock()
+
+	if a.server != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		if err := a.server.Shutdown(ctx); err != nil {
+			a.log.Warn("API server shutdown failed", "err", err)
+		}
+		cancel()
+		a.server = nil
+	}
+	if !cfg.Enabled {
+		return
+	}
+
+	token := cfg.Token
+	if token == "" {
+		path, err := TokenPath()
+		if err == nil {
+			token, err = server.LoadToken(path)
+		}
+		if err != nil {
+			a.log.Error("API server not started, no token", "err", err)
+			return
+		}
+	}
+
+	srv := server.New(serverBackend{app: a}, token, a.log.With("component", "server"))
+	if err := srv.Start(cfg.Port); err != nil {
+		a.log.Error("API server not started", "err", err)
+		return
+	}
+	a.server = srv
+}
@@ -0,0 +1,129 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/axide-dev/axidev-corrige/internal/checker"
+	"github.com/axide-dev/axidev-corrige/internal/logging"
+)
+
+// DictionaryInfo describes a language the settings view can select
+type DictionaryInfo struct {
+	Code      string `json:"code"`
+	Name      string `json:"name"`
+	Available bool   `json:"available"`
+	Active    bool   `json:"active"`
+}
+
+// PersonalWords lists the contents of the personal dictionary
+type PersonalWords struct {
+	Words   []string `json:"words"`
+	Ignored []string `json:"ignored"`
+	Learned []string `json:"learned"`
+}
+
+// GetConfig returns the active configuration (for UI binding). The API
+// token is left out, the frontend has no use for it.
+func (a *App) GetConfig() Config {
+	cfg := a.current().config
+	cfg.Server.Token = ""
+	return cfg
+}
+
+// SetConfig validates, applies and persists a configuration (for UI
+// binding). The API token is kept as the frontend never sees it.
+func (a *App) SetConfig(cfg Config) error {
+	a.configMu.Lock()
+	cfg.Server.Token = a.current().config.Server.Token
+	if err := a.applyConfigLocked(cfg); err != nil {
+		a.configMu.Unlock()
+		return err
+	}
+	err := a.saveConfig()
+	a.configMu.Unlock()
+
+	a.log.Info("Config updated from settings")
+	a.updateDisplay()
+	return err
+}
+
+// SetCorrectionMode switches between off, suggest, confirm and auto
+// (for UI binding)
+func (a *App) SetCorrectionMode(mode string) error {
+	cfg := a.current().config
+	cfg.Mode = CorrectionMode(mode)
+	return a.SetConfig(cfg)
+}
+
+// ListDictionaries returns the known languages and whether they are loaded
+// (for UI binding)
+func (a *App) ListDictionaries() []DictionaryInfo {
+	cfg := a.current().config
+
+	langs := checker.Languages()
+	result := make([]DictionaryInfo, len(langs))
+	for i, lang := range langs {
+		result[i] = DictionaryInfo{
+			Code:      lang.Code,
+			Name:      lang.Name,
+			Available: lang.Available(),
+			Active:    lang.Code == cfg.Language || slices.Contains(cfg.DetectLanguages, lang.Code),
+		}
+	}
+	return result
+}
+
+// GetPersonalWords returns the personal dictionary contents (for UI binding)
+func (a *App) GetPersonalWords() PersonalWords {
+	personal := a.current().personal
+	return PersonalWords{
+		Words:   personal.Words(),
+		Ignored: personal.Ignored(),
+		Learned: personal.Learned(),
+	}
+}
+
+// RemovePersonalWord forgets a word from the personal dictionary (for UI
+// binding). Dictionaries are reloaded when the word was a suggestion
+// candidate, since the spellchecker cannot drop words.
+func (a *App) RemovePersonalWord(word string) error {
+	word = strings.TrimSpace(word)
+
+	a.configMu.Lock()
+	defer a.configMu.Unlock()
+	current := a.current()
+
+	wasAdded := slices.ContainsFunc(current.personal.Words(), func(w string) bool {
+		return strings.EqualFold(w, word)
+	})
+	if err := current.personal.Remove(word); err != nil {
+		return fmt.Errorf("failed to save personal dictionary: %w", err)
+	}
+	a.log.Info("Removed from personal dictionary", logging.Secret("word", word))
+
+	if !wasAdded {
+		return nil
+	}
+
+	detector, err := a.loadDetector(current.config, current.personal)
+	if err != nil {
+		return err
+	}
+	next := *current
+	next.detector = detector
+	a.settings.Store(&next)
+	return nil
+}
+
+// saveConfig persists the active configuration to the config file, if any
+func (a *App) saveConfig() error {
+	if a.configPath == "" {
+		return nil
+	}
+	if err := SaveConfig(a.configPath, a.current().config); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	return nil
+}
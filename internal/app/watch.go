@@ -0,0 +1,48 @@
+package app
+
+import (
+	"context"
+	"os"
+	"time"
+)
+
+// configPollInterval is how often the config file is checked for changes
+const configPollInterval = time.Second
+
+// watchConfig polls the config file and applies it whenever its size or
+// modification time changes. Invalid edits are reported and the running
+// configuration is kept.
+func (a *App) watchConfig(ctx context.Context, path string) {
+	ticker := time.NewTicker(configPollInterval)
+	defer ticker.Stop()
+
+	last, _ := os.Stat(path)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		if last != nil && info.ModTime().Equal(last.ModTime()) && info.Size() == last.Size() {
+			continue
+		}
+		last = info
+
+		cfg, err := LoadConfig(path)
+		if err != nil {
+			a.log.Warn("Config not reloaded", "err", err)
+			continue
+		}
+		if err := a.applyConfig(cfg); err != nil {
+			a.log.Warn("Config not reloaded", "err", err)
+			continue
+		}
+		a.log.Info("Config reloaded")
+		a.updateDisplay()
+	}
+}
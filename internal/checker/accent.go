@@ -0,0 +1,86 @@
+package checker
+
+import (
+	"strings"
+)
+
+// diacritics maps lowercase accented letters to their plain spelling
+var diacritics = map[rune]string{
+	'à': "a", 'â': "a", 'ä': "a", 'á': "a", 'ã': "a", 'å': "a",
+	'ç': "c",
+	'é': "e", 'è': "e", 'ê': "e", 'ë': "e",
+	'î': "i", 'ï': "i", 'í': "i", 'ì': "i",
+	'ñ': "n",
+	'ô': "o", 'ö': "o", 'ó': "o", 'ò': "o", 'õ': "o",
+	'ù': "u", 'û': "u", 'ü': "u", 'ú': "u",
+	'ÿ': "y",
+	'œ': "oe", 'æ': "ae",
+}
+
+// StripAccents returns a lowercase word without diacritics: "Déjà" gives
+// "deja" and "cœur" gives "coeur"
+func StripAccents(word string) string {
+	word = strings.ToLower(word)
+	if !hasDiacritics(word) {
+		return word
+	}
+
+	var b strings.Builder
+	b.Grow(len(word))
+	for _, r := range word {
+		if plain, ok := diacritics[r]; ok {
+			b.WriteString(plain)
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// hasDiacritics returns true if the lowercase word has an accented letter
+func hasDiacritics(word string) bool {
+	for _, r := range word {
+		if _, ok := diacritics[r]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// accentIndex maps the plain spelling of accented dictionary words to
+// those words, "tres" to ["très"]
+type accentIndex map[string][]string
+
+// newAccentIndex indexes the accented words among the lowercase words
+func newAccentIndex(words []string) accentIndex {
+	index := make(accentIndex)
+	for _, word := range words {
+		if !hasDiacritics(word) {
+			continue
+		}
+		key := StripAccents(word)
+		if forms := index[key]; !containsString(forms, word) {
+			index[key] = append(forms, word)
+		}
+	}
+	return index
+}
+
+// unique returns the only dictionary word spelled like word without
+// accents, if there is exactly one and it differs from word
+func (idx accentIndex) unique(word string) (string, bool) {
+	forms := idx[StripAccents(word)]
+	if len(forms) != 1 || forms[0] == word {
+		return "", false
+	}
+	return forms[0], true
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+package checker
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Case is the capitalization pattern of a word
+type Case int
+
+const (
+	// CaseLower - no uppercase letter ("bonjour")
+	CaseLower Case = iota
+	// CaseTitle - only the first letter is uppercase ("Bonjour")
+	CaseTitle
+	// CaseUpper - every letter is uppercase, at least two of them ("PARIS")
+	CaseUpper
+	// CaseMixed - any other pattern ("iPhone")
+	CaseMixed
+)
+
+// CaseOf returns the capitalization pattern of word
+func CaseOf(word string) Case {
+	var letters, upper int
+	firstUpper := false
+	for _, r := range word {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		if unicode.IsUpper(r) {
+			if letters == 0 {
+				firstUpper = true
+			}
+			upper++
+		}
+		letters++
+	}
+
+	switch {
+	case upper == 0:
+		return CaseLower
+	case upper == letters && letters > 1:
+		return CaseUpper
+	case upper == 1 && firstUpper:
+		return CaseTitle
+	default:
+		return CaseMixed
+	}
+}
+
+// ApplyCase re-cases a dictionary word to the pattern c. Lowercase and
+// mixed patterns keep the word as stored, so proper nouns stay
+// capitalized.
+func ApplyCase(word string, c Case) string {
+	switch c {
+	case CaseUpper:
+		return strings.ToUpper(word)
+	case CaseTitle:
+		r, size := utf8.DecodeRuneInString(word)
+		return string(unicode.ToUpper(r)) + word[size:]
+	default:
+		return word
+	}
+}
+
+// properNouns maps the lowercase form of dictionary words listed only
+// capitalized ("Aaron") to that form. Words also listed in lowercase are
+// left out, since either case is then valid.
+func properNouns(words []string) map[string]string {
+	lower := make(map[string]bool, len(words))
+	for _, word := range words {
+		if CaseOf(word) == CaseLower {
+			lower[word] = true
+		}
+	}
+
+	proper := make(map[string]string)
+	for _, word := range words {
+		key := strings.ToLower(word)
+		if key != word && !lower[key] {
+			proper[key] = word
+		}
+	}
+	return proper
+}
+
+// casedLike returns true if word is written as the proper noun canonical
+// requires: as listed, or in all capitals
+func casedLike(word, canonical string) bool {
+	return word == canonical || (CaseOf(word) == CaseUpper && word == strings.ToUpper(canonical))
+}
@@ -2,24 +2,55 @@ package checker
 
 import (
 	"embed"
+	"fmt"
+	"log/slog"
+	"math"
 	"strings"
 
+	"github.com/axide-dev/axidev-corrige/internal/layout"
+	"github.com/axide-dev/axidev-corrige/internal/logging"
+	"github.com/axide-dev/axidev-corrige/internal/tokenize"
+
 	spellchecker "github.com/f1monkey/spellchecker/v3"
 )
 
 //go:embed francais.txt
-var frenchDictFS embed.FS
+var dictFS embed.FS
 
 // Checker wraps the spellchecker with additional functionality
 type Checker struct {
 	sc        *spellchecker.Spellchecker
+	lang      Language
 	wordCount int
+	personal  *PersonalDictionary
+	log       *slog.Logger
+	// proper maps proper nouns to their capitalized form, see properNouns
+	proper map[string]string
+	// accents indexes accented words by their plain spelling
+	accents accentIndex
+	// accented is false when the dictionary has no diacritics at all, so
+	// it cannot tell accented spellings apart
+	accented bool
+	// ranker reorders suggestions by the preceding words, if set
+	ranker Ranker
+	// layout weights typos on neighbouring keys, if set
+	layout *layout.Layout
+	// weight is given to personal and learned words: the median weight of
+	// the dictionary words, 1 when it has no frequencies
+	weight uint
+	// scoreScale brings suggestion scores of a frequency-weighted
+	// dictionary back to the range of an unweighted one for a word of
+	// median frequency, see addWeighted
+	scoreScale float64
 }
 
 // Suggestion represents a spelling suggestion
 type Suggestion struct {
 	Value string
 	Score float64
+	// Fixed is true for a suggestion only fixing capitalization or
+	// accents, which always comes first
+	Fixed bool
 }
 
 // Result holds spell check results
@@ -29,43 +60,107 @@ type Result struct {
 	Suggestions []Suggestion
 }
 
-// NewFrenchChecker creates a checker with the French dictionary
-func NewFrenchChecker() (*Checker, error) {
-	words, err := loadFrenchWords()
+// DefaultMinScore is the score the best suggestion needs by default to be
+// applied without asking
+const DefaultMinScore = 0.8
+
+// AutoCorrection returns the best suggestion if the word is misspelled and
+// the suggestion scores at least minScore or only fixes capitalization or
+// accents
+func (r Result) AutoCorrection(minScore float64) (Suggestion, bool) {
+	if r.IsCorrect || len(r.Suggestions) == 0 {
+		return Suggestion{}, false
+	}
+	best := r.Suggestions[0]
+	if !best.Fixed && best.Score < minScore {
+		return Suggestion{}, false
+	}
+	return best, true
+}
+
+// NewChecker creates a checker for the language registered under code
+func NewChecker(code string) (*Checker, error) {
+	lang, ok := LookupLanguage(code)
+	if !ok {
+		return nil, fmt.Errorf("unknown language %q", code)
+	}
+
+	words, freqs, err := lang.loadWords()
 	if err != nil {
 		return nil, err
 	}
 
-	// Initialize spellchecker with French alphabet
-	alphabet := "abcdefghijklmnopqrstuvwxyzàâäæçéèêëïîôùûüÿœ"
-	sc, err := spellchecker.New(alphabet)
+	sc, err := spellchecker.New(lang.alphabetFor(words))
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("invalid alphabet for %q: %w", code, err)
 	}
 
-	sc.AddMany(words)
+	// Words are matched in lowercase, the capitalization of proper nouns
+	// is checked separately. Frequent words get a higher weight so they
+	// win over rare ones at the same distance.
+	lower := make([]string, len(words))
+	for i, word := range words {
+		lower[i] = strings.ToLower(word)
+	}
+	weight := addWeighted(sc, lower, freqs)
+	accents := newAccentIndex(lower)
 
 	return &Checker{
-		sc:        sc,
-		wordCount: len(words),
+		sc:         sc,
+		lang:       lang,
+		wordCount:  len(words),
+		log:        slog.New(slog.DiscardHandler),
+		proper:     properNouns(words),
+		accents:    accents,
+		accented:   len(accents) > 0,
+		weight:     weight,
+		scoreScale: math.Log1p(1) / math.Log1p(float64(weight)),
 	}, nil
 }
 
-func loadFrenchWords() ([]string, error) {
-	data, err := frenchDictFS.ReadFile("francais.txt")
-	if err != nil {
-		return nil, err
+// NewFrenchChecker creates a checker with the French dictionary
+func NewFrenchChecker() (*Checker, error) {
+	return NewChecker("fr")
+}
+
+// Language returns the language of the loaded dictionary
+func (c *Checker) Language() Language {
+	return c.lang
+}
+
+// SetLogger sets the logger used for dictionary changes
+func (c *Checker) SetLogger(log *slog.Logger) {
+	c.log = log.With("language", c.lang.Code)
+}
+
+// SetPersonal merges the personal dictionary into the checker. Its words
+// are accepted in any case and added ones become suggestion candidates.
+func (c *Checker) SetPersonal(p *PersonalDictionary) {
+	c.personal = p
+	if p == nil {
+		return
+	}
+	words := p.Words()
+	for _, word := range words {
+		c.addCandidate(word)
 	}
+	c.log.Debug("Personal dictionary merged", "words", len(words))
+}
 
-	lines := strings.Split(string(data), "\n")
-	words := make([]string, 0, len(lines))
-	for _, line := range lines {
-		word := strings.TrimSpace(line)
-		if word != "" {
-			words = append(words, word)
-		}
+// Learn adds a word to the suggestion candidates of the loaded dictionary
+func (c *Checker) Learn(word string) {
+	c.addCandidate(word)
+	c.log.Debug("Learned word", logging.Secret("word", word))
+}
+
+// addCandidate adds a word to the spellchecker unless it is already known,
+// since adding again would raise its weight over the other words each time
+// the personal dictionary is merged
+func (c *Checker) addCandidate(word string) {
+	lower := strings.ToLower(word)
+	if !c.sc.IsCorrect(lower) {
+		c.sc.Add(lower, spellchecker.AddWithWeight(c.weight))
 	}
-	return words, nil
 }
 
 // WordCount returns the number of words in the dictionary
@@ -73,15 +168,48 @@ func (c *Checker) WordCount() int {
 	return c.wordCount
 }
 
-// IsCorrect checks if a word is spelled correctly
+// IsCorrect checks if a word is spelled correctly. Proper nouns must be
+// capitalized as in the dictionary or written in all capitals.
 func (c *Checker) IsCorrect(word string) bool {
-	return c.sc.IsCorrect(strings.ToLower(word))
+	if c.personal != nil && c.personal.Has(word) {
+		return true
+	}
+	lower := strings.ToLower(word)
+	if c.sc.IsCorrect(lower) {
+		canonical, proper := c.proper[lower]
+		return !proper || casedLike(word, canonical)
+	}
+	if plain := StripAccents(lower); !c.accented && plain != lower && c.sc.IsCorrect(plain) {
+		return true
+	}
+
+	// Hyphenated compounds missing from the dictionary are accepted when
+	// each component is ("dis-moi", "a-t-il")
+	parts := tokenize.Compound(word)
+	if len(parts) < 2 {
+		return false
+	}
+	for _, part := range parts {
+		if !c.IsCorrect(part) {
+			return false
+		}
+	}
+	return true
 }
 
-// Check performs a full spell check on a word
+// Check performs a full spell check on a word. Suggestions follow the
+// capitalization of the word: "Bonjor" gives "Bonjour", "BONJOR" gives
+// "BONJOUR", and proper nouns keep their capital.
 func (c *Checker) Check(word string, maxSuggestions int) Result {
+	return c.CheckContext(word, nil, maxSuggestions)
+}
+
+// CheckContext is Check for a word typed after the words of context, most
+// recent last. With a ranker set, suggestions are ordered by how well they
+// follow them.
+func (c *Checker) CheckContext(word string, context []string, maxSuggestions int) Result {
 	wordLower := strings.ToLower(word)
-	isCorrect := c.sc.IsCorrect(wordLower)
+	isCorrect := c.IsCorrect(word)
 
 	result := Result{
 		Original:  word,
@@ -89,12 +217,68 @@ func (c *Checker) Check(word string, maxSuggestions int) Result {
 	}
 
 	if !isCorrect && maxSuggestions > 0 {
-		scResult := c.sc.Suggest(wordLower, maxSuggestions)
-		result.Suggestions = make([]Suggestion, len(scResult.Suggestions))
-		for i, s := range scResult.Suggestions {
-			result.Suggestions[i] = Suggestion{
-				Value: s.Value,
-				Score: s.Score,
+		limit := maxSuggestions
+		ranked := c.ranker != nil && len(context) > 0
+		if ranked {
+			limit = max(limit, rankPool)
+		}
+		if c.layout != nil {
+			limit = max(limit, layoutPool)
+		}
+		scResult := c.sc.Suggest(wordLower, limit)
+		c.weighTypos(wordLower, scResult.Suggestions)
+		wordCase := CaseOf(word)
+		result.Suggestions = make([]Suggestion, 0, len(scResult.Suggestions)+1)
+		seen := make(map[string]bool, len(scResult.Suggestions)+1)
+
+		// A proper noun typed in the wrong case only needs its capital, and
+		// a word matching a single accented form only needs its accents
+		fix, ok := c.proper[wordLower]
+		if !ok || !c.sc.IsCorrect(wordLower) {
+			fix, ok = c.accents.unique(wordLower)
+			if canonical, proper := c.proper[fix]; proper {
+				fix = canonical
+			}
+		}
+		if ok {
+			value := ApplyCase(fix, wordCase)
+			seen[value] = true
+			result.Suggestions = append(result.Suggestions, Suggestion{Value: value, Fixed: true})
+		}
+
+		for _, s := range scResult.Suggestions {
+			if len(result.Suggestions) == limit {
+				break
+			}
+			value := s.Value
+			if canonical, ok := c.proper[value]; ok {
+				value = canonical
+			}
+			value = ApplyCase(value, wordCase)
+			if value == word || seen[value] {
+				continue
+			}
+			seen[value] = true
+			result.Suggestions = append(result.Suggestions, Suggestion{
+				Value: value,
+				Score: s.Score * c.scoreScale,
+			})
+		}
+
+		if limit > maxSuggestions {
+			if ranked {
+				c.rerank(context, result.Suggestions)
+			}
+			result.Suggestions = result.Suggestions[:min(len(result.Suggestions), maxSuggestions)]
+		}
+
+		// A fix is kept first by Fixed, its score only matters for display:
+		// that of the best spelling edit, at least 1
+		if len(result.Suggestions) > 0 && result.Suggestions[0].Fixed {
+			first := &result.Suggestions[0]
+			first.Score = 1
+			for _, s := range result.Suggestions[1:] {
+				first.Score = max(first.Score, s.Score)
 			}
 		}
 	}
@@ -0,0 +1,52 @@
+package checker
+
+import "testing"
+
+func TestAutoCorrection(t *testing.T) {
+	tests := []struct {
+		name   string
+		result Result
+		want   string
+	}{
+		{"correct word", Result{IsCorrect: true, Suggestions: []Suggestion{{Value: "mot", Score: 5}}}, ""},
+		{"no suggestion", Result{}, ""},
+		{"confident", Result{Suggestions: []Suggestion{{Value: "maison", Score: 1.5}}}, "maison"},
+		{"below threshold", Result{Suggestions: []Suggestion{{Value: "maison", Score: 0.3}}}, ""},
+		{"fix below threshold", Result{Suggestions: []Suggestion{{Value: "École", Score: 0.3, Fixed: true}}}, "École"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.result.AutoCorrection(0.8)
+			if got.Value != tt.want || ok != (tt.want != "") {
+				t.Errorf("AutoCorrection() = %q, %v, want %q", got.Value, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckFixesComeFirst(t *testing.T) {
+	isolateUserData(t)
+
+	c, err := NewChecker("fr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for word, want := range map[string]string{"abidjan": "Abidjan", "ABIDJAn": "Abidjan"} {
+		result := c.Check(word, 3)
+		if len(result.Suggestions) == 0 {
+			t.Fatalf("Check(%q) has no suggestion", word)
+		}
+		first := result.Suggestions[0]
+		if !first.Fixed || first.Value != want {
+			t.Errorf("Check(%q) first suggestion = %+v, want fix %q", word, first, want)
+		}
+		for _, s := range result.Suggestions[1:] {
+			if s.Fixed || s.Score > first.Score {
+				t.Errorf("Check(%q): %+v ranks above the fix %+v", word, s, first)
+			}
+		}
+		if _, ok := result.AutoCorrection(DefaultMinScore); !ok {
+			t.Errorf("Check(%q) fix is not applied automatically", word)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package checker
+
+// DefaultDetectionWindow is the number of recent words used for detection
+const DefaultDetectionWindow = 8
+
+// Detector picks the language of a word among several loaded dictionaries
+// by looking at the words typed before it
+type Detector struct {
+	checkers []*Checker
+	window   int
+}
+
+// NewDetector creates a detector over the given checkers. The first checker
+// is the fallback used when the context does not favour any language.
+func NewDetector(checkers []*Checker, window int) *Detector {
+	if window <= 0 {
+		window = DefaultDetectionWindow
+	}
+	return &Detector{
+		checkers: checkers,
+		window:   window,
+	}
+}
+
+// Checkers returns the checkers the detector chooses from
+func (d *Detector) Checkers() []*Checker {
+	return d.checkers
+}
+
+// Detect returns the checker whose dictionary recognises the most words
+// among the last words of context. Ties go to the earliest checker.
+func (d *Detector) Detect(context []string) *Checker {
+	if len(d.checkers) == 0 {
+		return nil
+	}
+	if len(d.checkers) == 1 {
+		return d.checkers[0]
+	}
+
+	if len(context) > d.window {
+		context = context[len(context)-d.window:]
+	}
+
+	best := d.checkers[0]
+	bestScore := -1
+	for _, c := range d.checkers {
+		score := 0
+		for _, word := range context {
+			if c.IsCorrect(word) {
+				score++
+			}
+		}
+		if score > bestScore {
+			best = c
+			bestScore = score
+		}
+	}
+	return best
+}
+
+// CheckerFor returns the checker to use for word. A language that already
+// accepts the word wins so valid words are never corrected into another
+// language; otherwise the context decides.
+func (d *Detector) CheckerFor(word string, context []string) *Checker {
+	if len(d.checkers) > 1 {
+		for _, c := range d.checkers {
+			if c.IsCorrect(word) {
+				return c
+			}
+		}
+	}
+	return d.Detect(context)
+}
@@ -0,0 +1,94 @@
+package checker
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// englishWords is a small English dictionary for detection tests
+var englishWords = []string{
+	"the", "cat", "is", "on", "table", "dog", "sleeps", "in", "house", "a", "garden",
+}
+
+// newTestDetector returns a detector over French, then English
+func newTestDetector(t *testing.T) *Detector {
+	t.Helper()
+	dir := isolateUserData(t)
+	data := strings.Join(englishWords, "\n") + "\n"
+	if err := os.WriteFile(filepath.Join(dir, "en.txt"), []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var checkers []*Checker
+	for _, code := range []string{"fr", "en"} {
+		c, err := NewChecker(code)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkers = append(checkers, c)
+	}
+	return NewDetector(checkers, 0)
+}
+
+func TestDetect(t *testing.T) {
+	d := newTestDetector(t)
+
+	tests := []struct {
+		name    string
+		context string
+		want    string
+	}{
+		{"french sentence", "le chat dort sur la table", "fr"},
+		{"english sentence", "the dog sleeps in the garden", "en"},
+		{"empty context falls back to the first", "", "fr"},
+		{"unknown words fall back to the first", "xqzt wvvk", "fr"},
+		{"tie falls back to the first", "the chat", "fr"},
+		{"only the window counts", "le chat dort sur la table the dog sleeps in the house on the table", "en"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := d.Detect(strings.Fields(tt.context)).Language().Code; got != tt.want {
+				t.Errorf("Detect(%q) = %s, want %s", tt.context, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckerFor(t *testing.T) {
+	d := newTestDetector(t)
+
+	tests := []struct {
+		name    string
+		word    string
+		context string
+		want    string
+	}{
+		{"valid word keeps its language", "garden", "le chat dort dans le", "en"},
+		{"valid word in the first language wins", "maison", "the dog sleeps in the", "fr"},
+		{"misspelling follows the context", "gardn", "the dog sleeps in the", "en"},
+		{"misspelling in french context", "jardn", "le chat dort dans le", "fr"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := d.CheckerFor(tt.word, strings.Fields(tt.context)).Language().Code; got != tt.want {
+				t.Errorf("CheckerFor(%q) = %s, want %s", tt.word, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectSingleChecker(t *testing.T) {
+	isolateUserData(t)
+	c, err := NewChecker("fr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := NewDetector([]*Checker{c}, 0).Detect([]string{"the", "dog"}); got != c {
+		t.Error("a single checker is not always chosen")
+	}
+	if got := NewDetector(nil, 0).Detect(nil); got != nil {
+		t.Error("a detector without checkers returned one")
+	}
+}
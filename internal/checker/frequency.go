@@ -0,0 +1,130 @@
+package checker
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/axide-dev/axidev-corrige/internal/tokenize"
+
+	spellchecker "github.com/f1monkey/spellchecker/v3"
+)
+
+// maxWeight is the spellchecker weight of the most frequent dictionary
+// word. Words without a frequency weigh 1, like in an unweighted list.
+const maxWeight = 10
+
+// WordFrequency is a dictionary word and how often it occurs
+type WordFrequency struct {
+	Word  string
+	Count uint64
+}
+
+// frequencyWeight maps a frequency to a weight from 1 to maxWeight on a log
+// scale, so "pour" is not a thousand times more likely than "pourpre"
+func frequencyWeight(freq, maxFreq uint64) uint {
+	if freq == 0 || maxFreq == 0 {
+		return 1
+	}
+	return 1 + uint(math.Round((maxWeight-1)*math.Log1p(float64(freq))/math.Log1p(float64(maxFreq))))
+}
+
+// addWeighted adds the lowercase words to sc, weighted by their frequency,
+// and returns the median weight. Each word is added once: case variants
+// such as "Paris" and "paris" are lowercased by the caller, and adding a
+// word again would add to its weight.
+func addWeighted(sc *spellchecker.Spellchecker, words []string, freqs map[string]uint64) uint {
+	var maxFreq uint64
+	for _, f := range freqs {
+		maxFreq = max(maxFreq, f)
+	}
+
+	groups := make(map[uint][]string)
+	weights := make([]uint, 0, len(words))
+	seen := make(map[string]bool, len(words))
+	for _, word := range words {
+		if seen[word] {
+			continue
+		}
+		seen[word] = true
+		w := frequencyWeight(freqs[word], maxFreq)
+		groups[w] = append(groups[w], word)
+		weights = append(weights, w)
+	}
+	for w, group := range groups {
+		sc.AddMany(group, spellchecker.AddWithWeight(w))
+	}
+
+	if len(weights) == 0 {
+		return 1
+	}
+	sort.Slice(weights, func(i, j int) bool { return weights[i] < weights[j] })
+	return weights[len(weights)/2]
+}
+
+// CountFrequencies counts how often each word of the dictionary of the
+// language registered under code occurs in the corpus files, ignoring
+// case. Each file is read on its own, so a word at the end of one never
+// runs into the start of the next. Every dictionary word is returned, most
+// frequent first.
+func CountFrequencies(code string, corpus ...io.Reader) ([]WordFrequency, error) {
+	lang, ok := LookupLanguage(code)
+	if !ok {
+		return nil, fmt.Errorf("unknown language %q", code)
+	}
+	words, _, err := lang.loadWords()
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]uint64)
+	for _, r := range corpus {
+		if err := countWords(r, counts); err != nil {
+			return nil, err
+		}
+	}
+
+	list := make([]WordFrequency, 0, len(words))
+	seen := make(map[string]bool, len(words))
+	for _, word := range words {
+		if seen[word] {
+			continue
+		}
+		seen[word] = true
+		list = append(list, WordFrequency{Word: word, Count: counts[strings.ToLower(word)]})
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].Count != list[j].Count {
+			return list[i].Count > list[j].Count
+		}
+		return list[i].Word < list[j].Word
+	})
+	return list, nil
+}
+
+// countWords adds the lowercase words of r to counts
+func countWords(r io.Reader, counts map[string]uint64) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		for _, token := range tokenize.Scan(scanner.Text()) {
+			counts[strings.ToLower(token.Parts.Word)]++
+		}
+	}
+	return scanner.Err()
+}
+
+// WriteFrequencies writes list as a dictionary word list, each word
+// followed by a tab and its count
+func WriteFrequencies(w io.Writer, list []WordFrequency) error {
+	bw := bufio.NewWriter(w)
+	for _, wf := range list {
+		if _, err := fmt.Fprintf(bw, "%s\t%d\n", wf.Word, wf.Count); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,53 @@
+package checker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCaseVariantsCountOnce(t *testing.T) {
+	tests := []struct {
+		name string
+		list string
+	}{
+		{"unweighted", "Paris\nparis\ntaris\n"},
+		{"weighted", "Paris\t100\nparis\t40\ntaris\t100\n"},
+		{"weighted, lowercase first", "paris\t40\nPARIS\t100\ntaris\t100\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := isolateUserData(t)
+			if err := os.WriteFile(filepath.Join(dir, "en.txt"), []byte(tt.list), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			c, err := NewChecker("en")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := scores(c.Suggest("varis", 5))
+			if got["paris"] == 0 || got["paris"] != got["taris"] {
+				t.Errorf("scores = %v, want paris and taris equal", got)
+			}
+		})
+	}
+}
+
+func TestFrequencyWeight(t *testing.T) {
+	tests := []struct {
+		freq, maxFreq uint64
+		want          uint
+	}{
+		{0, 0, 1},
+		{0, 1000, 1},
+		{1000, 1000, maxWeight},
+		{1, 1000, 2},
+		{31, 1000, 6},
+	}
+	for _, tt := range tests {
+		if got := frequencyWeight(tt.freq, tt.maxFreq); got != tt.want {
+			t.Errorf("frequencyWeight(%d, %d) = %d, want %d", tt.freq, tt.maxFreq, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,286 @@
+package checker
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/axide-dev/axidev-corrige/internal/hunspell"
+	"github.com/axide-dev/axidev-corrige/internal/paths"
+	"github.com/axide-dev/axidev-corrige/internal/tokenize"
+)
+
+// Language describes a dictionary and the alphabet used to index it
+type Language struct {
+	// Code is the short identifier used in configuration (e.g. "fr")
+	Code string
+	// Name is the human readable language name
+	Name string
+	// Alphabet lists the lowercase letters of the language. When empty,
+	// it is derived from the dictionary contents.
+	Alphabet string
+	// Embedded is the file name of a dictionary bundled with the binary,
+	// empty if the dictionary must be provided in the user data directory
+	Embedded string
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Language{}
+)
+
+func init() {
+	Register(Language{
+		Code:     "fr",
+		Name:     "Français",
+		Alphabet: "abcdefghijklmnopqrstuvwxyzàâäæçéèêëïîôùûüÿœ",
+		Embedded: "francais.txt",
+	})
+}
+
+// presets name the languages whose dictionary is not shipped and give
+// their alphabet, applied once the user supplies the dictionary file
+var presets = map[string]Language{
+	"en": {Code: "en", Name: "English", Alphabet: "abcdefghijklmnopqrstuvwxyz"},
+	"de": {Code: "de", Name: "Deutsch", Alphabet: "abcdefghijklmnopqrstuvwxyzäöüß"},
+}
+
+// userLanguage describes a language found in the user data directory
+func userLanguage(code string) Language {
+	if lang, ok := presets[code]; ok {
+		return lang
+	}
+	return Language{Code: code, Name: code}
+}
+
+// Register adds or replaces a language in the registry
+func Register(lang Language) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[lang.Code] = lang
+}
+
+// LookupLanguage returns the language registered under code. Languages
+// that are not registered but have a dictionary file in the user data
+// directory are returned with their preset alphabet, or one derived from
+// that file.
+func LookupLanguage(code string) (Language, bool) {
+	registryMu.RLock()
+	lang, ok := registry[code]
+	registryMu.RUnlock()
+	if ok {
+		return lang, true
+	}
+
+	if _, err := userDictionaryPath(code); err == nil {
+		return userLanguage(code), true
+	}
+	return Language{}, false
+}
+
+// Languages returns all known languages sorted by code, including those
+// only available as files in the user data directory
+func Languages() []Language {
+	registryMu.RLock()
+	langs := make(map[string]Language, len(registry))
+	for code, lang := range registry {
+		langs[code] = lang
+	}
+	registryMu.RUnlock()
+
+	for _, code := range userDictionaryCodes() {
+		if _, ok := langs[code]; !ok {
+			langs[code] = userLanguage(code)
+		}
+	}
+
+	result := make([]Language, 0, len(langs))
+	for _, lang := range langs {
+		result = append(result, lang)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Code < result[j].Code
+	})
+	return result
+}
+
+// Available reports whether a dictionary can be loaded for the language
+func (l Language) Available() bool {
+	if l.Embedded != "" {
+		return true
+	}
+	_, err := userDictionaryPath(l.Code)
+	return err == nil
+}
+
+// loadWords reads the language dictionary, preferring a user-supplied
+// file over the embedded one. Word frequencies are keyed by the lowercase
+// word and nil when the dictionary has none.
+func (l Language) loadWords() ([]string, map[string]uint64, error) {
+	if path, err := userDictionaryPath(l.Code); err == nil {
+		if filepath.Ext(path) == ".dic" {
+			words, err := loadHunspell(path)
+			return words, nil, err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		words, freqs, err := parseWordList(data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid dictionary %s: %w", path, err)
+		}
+		return words, freqs, nil
+	}
+
+	if l.Embedded == "" {
+		return nil, nil, fmt.Errorf("no dictionary found for language %q", l.Code)
+	}
+
+	data, err := dictFS.ReadFile(l.Embedded)
+	if err != nil {
+		return nil, nil, err
+	}
+	return parseWordList(data)
+}
+
+// alphabetFor returns the language alphabet, deriving it from the words
+// if none was registered
+func (l Language) alphabetFor(words []string) string {
+	if l.Alphabet != "" {
+		return l.Alphabet
+	}
+
+	seen := make(map[rune]bool)
+	var b strings.Builder
+	for _, word := range words {
+		for _, r := range strings.ToLower(word) {
+			if !seen[r] {
+				seen[r] = true
+				b.WriteRune(r)
+			}
+		}
+	}
+	return b.String()
+}
+
+// loadHunspell expands a Hunspell dictionary into its inflected forms.
+// Forms starting with an elision ("l'arbre") are left out as elisions are
+// checked apart from the word.
+func loadHunspell(dicPath string) ([]string, error) {
+	d, err := hunspell.Load(affixPath(dicPath), dicPath)
+	if err != nil {
+		return nil, err
+	}
+
+	forms := d.Words()
+	words := forms[:0]
+	for _, form := range forms {
+		if tokenize.Split(form).Prefix == "" {
+			words = append(words, form)
+		}
+	}
+	slog.Debug("Hunspell dictionary expanded", "path", dicPath, "roots", d.RootCount(), "words", len(words))
+	return words, nil
+}
+
+// userDictionaryPath returns the user-supplied dictionary for code: a
+// Hunspell pair <code>.dic and <code>.aff, or else a <code>.txt word list
+func userDictionaryPath(code string) (string, error) {
+	dir, err := paths.DictionaryDir()
+	if err != nil {
+		return "", err
+	}
+
+	dic := filepath.Join(dir, code+".dic")
+	if _, err := os.Stat(dic); err == nil {
+		if _, err := os.Stat(affixPath(dic)); err == nil {
+			return dic, nil
+		}
+	}
+
+	path := filepath.Join(dir, code+".txt")
+	if _, err := os.Stat(path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// affixPath returns the affix file going with a Hunspell .dic file
+func affixPath(dicPath string) string {
+	return strings.TrimSuffix(dicPath, ".dic") + ".aff"
+}
+
+func userDictionaryCodes() []string {
+	dir, err := paths.DictionaryDir()
+	if err != nil {
+		return nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			slog.Warn("Failed to read dictionary directory", "err", err)
+		}
+		return nil
+	}
+
+	codes := make([]string, 0, len(entries))
+	seen := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		ext := filepath.Ext(name)
+		if entry.IsDir() || (ext != ".txt" && ext != ".dic") {
+			continue
+		}
+		if ext == ".dic" {
+			if _, err := os.Stat(affixPath(filepath.Join(dir, name))); err != nil {
+				continue
+			}
+		}
+		code := strings.TrimSuffix(name, ext)
+		if !seen[code] {
+			seen[code] = true
+			codes = append(codes, code)
+		}
+	}
+	return codes
+}
+
+// parseWordList reads a word list, one word per line, optionally followed
+// by a tab and how often the word occurs. A word listed several times, in
+// different cases, keeps its highest frequency; the map is nil when no line
+// has one.
+func parseWordList(data []byte) ([]string, map[string]uint64, error) {
+	lines := strings.Split(string(data), "\n")
+	words := make([]string, 0, len(lines))
+	var freqs map[string]uint64
+	for i, line := range lines {
+		word, count, hasCount := strings.Cut(line, "\t")
+		word = strings.TrimSpace(word)
+		if word == "" {
+			continue
+		}
+		words = append(words, word)
+		if !hasCount {
+			continue
+		}
+
+		n, err := strconv.ParseUint(strings.TrimSpace(count), 10, 64)
+		if err != nil {
+			return nil, nil, fmt.Errorf("line %d: invalid frequency %q", i+1, strings.TrimSpace(count))
+		}
+		if freqs == nil {
+			freqs = make(map[string]uint64)
+		}
+		lower := strings.ToLower(word)
+		freqs[lower] = max(freqs[lower], n)
+	}
+	return words, freqs, nil
+}
@@ -0,0 +1,51 @@
+package checker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// isolateUserData points the user data directory at an empty temporary
+// directory and returns the dictionary directory inside it
+func isolateUserData(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_DATA_HOME", filepath.Join(home, "data"))
+	dir := filepath.Join(home, "data", "axidev-corrige", "dictionaries")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func languageCodes() []string {
+	var codes []string
+	for _, lang := range Languages() {
+		codes = append(codes, lang.Code)
+	}
+	return codes
+}
+
+func TestLanguagesOnlyWithDictionary(t *testing.T) {
+	dir := isolateUserData(t)
+
+	if codes := languageCodes(); len(codes) != 1 || codes[0] != "fr" {
+		t.Fatalf("Languages() = %q, want only the shipped fr", codes)
+	}
+	if _, ok := LookupLanguage("en"); ok {
+		t.Error("en is available without a dictionary")
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "en.txt"), []byte("hello\nworld\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	lang, ok := LookupLanguage("en")
+	if !ok || lang.Name != "English" || lang.Alphabet != presets["en"].Alphabet {
+		t.Errorf("LookupLanguage(en) = %+v, %v, want the preset", lang, ok)
+	}
+	if codes := languageCodes(); len(codes) != 2 || codes[0] != "en" {
+		t.Errorf("Languages() = %q, want en and fr", codes)
+	}
+}
@@ -0,0 +1,194 @@
+package checker
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/axide-dev/axidev-corrige/internal/paths"
+)
+
+// PersonalDictionary holds words taught by the user, persisted as JSON.
+// Added words are accepted and offered as suggestions; ignored words are
+// only accepted. Learned words are ignored words recorded automatically
+// when the user undoes a correction.
+type PersonalDictionary struct {
+	path    string
+	mu      sync.RWMutex
+	words   map[string]string
+	ignored map[string]string
+	learned map[string]string
+}
+
+// personalFile is the on-disk representation of a PersonalDictionary
+type personalFile struct {
+	Words   []string `json:"words"`
+	Ignored []string `json:"ignored"`
+	Learned []string `json:"learned,omitempty"`
+}
+
+// DefaultPersonalDictionaryPath returns the personal dictionary location
+// in the user config directory
+func DefaultPersonalDictionaryPath() (string, error) {
+	dir, err := paths.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "personal.json"), nil
+}
+
+// LoadPersonalDictionary reads the personal dictionary at path. A missing
+// file yields an empty dictionary that is created on first change.
+func LoadPersonalDictionary(path string) (*PersonalDictionary, error) {
+	p := &PersonalDictionary{
+		path:    path,
+		words:   make(map[string]string),
+		ignored: make(map[string]string),
+		learned: make(map[string]string),
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return p, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var file personalFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid personal dictionary %s: %w", path, err)
+	}
+	for _, word := range file.Words {
+		p.words[personalKey(word)] = word
+	}
+	for _, word := range file.Ignored {
+		p.ignored[personalKey(word)] = word
+	}
+	for _, word := range file.Learned {
+		p.learned[personalKey(word)] = word
+	}
+	return p, nil
+}
+
+// Path returns the file backing the dictionary
+func (p *PersonalDictionary) Path() string {
+	return p.path
+}
+
+// Has reports whether the word was added, ignored or learned
+func (p *PersonalDictionary) Has(word string) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	key := personalKey(word)
+	_, added := p.words[key]
+	_, ignored := p.ignored[key]
+	_, learned := p.learned[key]
+	return added || ignored || learned
+}
+
+// Words returns the added words, sorted
+func (p *PersonalDictionary) Words() []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return sortedValues(p.words)
+}
+
+// Ignored returns the ignored words, sorted
+func (p *PersonalDictionary) Ignored() []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return sortedValues(p.ignored)
+}
+
+// Learned returns the automatically learned words, sorted
+func (p *PersonalDictionary) Learned() []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return sortedValues(p.learned)
+}
+
+// Add teaches the word and persists the dictionary
+func (p *PersonalDictionary) Add(word string) error {
+	return p.update(func() {
+		key := personalKey(word)
+		delete(p.ignored, key)
+		delete(p.learned, key)
+		p.words[key] = word
+	})
+}
+
+// Ignore accepts the word without suggesting it and persists the dictionary
+func (p *PersonalDictionary) Ignore(word string) error {
+	return p.update(func() {
+		key := personalKey(word)
+		if _, added := p.words[key]; !added {
+			p.ignored[key] = word
+		}
+	})
+}
+
+// AddLearned records a word the user restored after a correction so it is
+// no longer corrected, and persists the dictionary
+func (p *PersonalDictionary) AddLearned(word string) error {
+	return p.update(func() {
+		key := personalKey(word)
+		_, added := p.words[key]
+		_, ignored := p.ignored[key]
+		if !added && !ignored {
+			p.learned[key] = word
+		}
+	})
+}
+
+// Remove forgets the word and persists the dictionary
+func (p *PersonalDictionary) Remove(word string) error {
+	return p.update(func() {
+		key := personalKey(word)
+		delete(p.words, key)
+		delete(p.ignored, key)
+		delete(p.learned, key)
+	})
+}
+
+// update applies fn under the lock and saves the result
+func (p *PersonalDictionary) update(fn func()) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	fn()
+
+	data, err := json.MarshalIndent(personalFile{
+		Words:   sortedValues(p.words),
+		Ignored: sortedValues(p.ignored),
+		Learned: sortedValues(p.learned),
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(p.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(p.path), err)
+	}
+	return os.WriteFile(p.path, data, 0o644)
+}
+
+func personalKey(word string) string {
+	return strings.ToLower(strings.TrimSpace(word))
+}
+
+func sortedValues(m map[string]string) []string {
+	values := make([]string, 0, len(m))
+	for _, v := range m {
+		values = append(values, v)
+	}
+	sort.Strings(values)
+	return values
+}
@@ -0,0 +1,53 @@
+package checker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// scores maps suggested words to their score
+func scores(suggestions []Suggestion) map[string]float64 {
+	result := make(map[string]float64, len(suggestions))
+	for _, s := range suggestions {
+		result[s.Value] = s.Score
+	}
+	return result
+}
+
+func TestSetPersonalKeepsSuggestionOrder(t *testing.T) {
+	isolateUserData(t)
+	path := filepath.Join(t.TempDir(), "personal.json")
+	data := `{"words": ["raisin", "Maisinette"], "ignored": [], "learned": []}`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	personal, err := LoadPersonalDictionary(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := NewChecker("fr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetPersonal(personal)
+	want := scores(c.Suggest("maisin", 5))
+
+	for range 3 {
+		c.SetPersonal(personal)
+		c.Learn("raisin")
+	}
+	got := scores(c.Suggest("maisin", 5))
+	for _, word := range []string{"maison", "raisin"} {
+		if got[word] != want[word] {
+			t.Errorf("after reloading, %s scores %v, want %v", word, got[word], want[word])
+		}
+	}
+	if got["raisin"] != got["maison"] {
+		t.Errorf("raisin scores %v and maison %v, want a tie", got["raisin"], got["maison"])
+	}
+	if !c.IsCorrect("maisinette") {
+		t.Error("personal word not accepted")
+	}
+}
@@ -0,0 +1,56 @@
+package checker
+
+import (
+	"sort"
+)
+
+// Ranker scores how well a word follows the words typed before it, such
+// as an n-gram language model
+type Ranker interface {
+	Score(context []string, word string) float64
+}
+
+// rankPool is the number of candidates a ranker chooses from
+const rankPool = 10
+
+// SetRanker makes suggestions follow the preceding words, nil restores
+// the spellchecker order
+func (c *Checker) SetRanker(r Ranker) {
+	c.ranker = r
+}
+
+// rerank reorders suggestions by their spellchecker score weighted by how
+// well they follow context compared to the other candidates. The weight
+// is between 1/3 and 2, and 1 for a candidate as likely as the average, so
+// scores stay comparable with the auto-correction threshold. Capitalization
+// and accent fixes keep their place.
+func (c *Checker) rerank(context []string, suggestions []Suggestion) {
+	start := 0
+	for start < len(suggestions) && suggestions[start].Fixed {
+		start++
+	}
+	candidates := suggestions[start:]
+	if len(candidates) < 2 {
+		return
+	}
+
+	probs := make([]float64, len(candidates))
+	var sum float64
+	for i, s := range candidates {
+		probs[i] = c.ranker.Score(context, s.Value)
+		sum += probs[i]
+	}
+	if sum == 0 {
+		return
+	}
+
+	avg := sum / float64(len(candidates))
+	smoothing := avg / 4
+	for i := range candidates {
+		weight := 2 * (probs[i] + smoothing) / (probs[i] + avg + 2*smoothing)
+		candidates[i].Score *= weight
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+}
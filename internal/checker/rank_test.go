@@ -0,0 +1,77 @@
+package checker
+
+import (
+	"reflect"
+	"testing"
+)
+
+// fakeRanker scores words from a fixed table, whatever the context
+type fakeRanker map[string]float64
+
+func (r fakeRanker) Score(_ []string, word string) float64 {
+	return r[word]
+}
+
+func values(suggestions []Suggestion) []string {
+	result := make([]string, len(suggestions))
+	for i, s := range suggestions {
+		result[i] = s.Value
+	}
+	return result
+}
+
+func TestRerank(t *testing.T) {
+	tests := []struct {
+		name        string
+		ranker      fakeRanker
+		suggestions []Suggestion
+		want        []string
+	}{
+		{
+			name:   "likely word moves up",
+			ranker: fakeRanker{"chine": 0.001, "chien": 0.2},
+			suggestions: []Suggestion{
+				{Value: "chine", Score: 1.2},
+				{Value: "chien", Score: 1},
+			},
+			want: []string{"chien", "chine"},
+		},
+		{
+			name:   "high spellchecker scores are reranked",
+			ranker: fakeRanker{"constitutionnelle": 0.001, "constitutionnelles": 0.3},
+			suggestions: []Suggestion{
+				{Value: "constitutionnelle", Score: 14},
+				{Value: "constitutionnelles", Score: 12},
+			},
+			want: []string{"constitutionnelles", "constitutionnelle"},
+		},
+		{
+			name:   "fixes keep their place",
+			ranker: fakeRanker{"École": 0, "écale": 0.001, "écoles": 0.3},
+			suggestions: []Suggestion{
+				{Value: "École", Score: 0.5, Fixed: true},
+				{Value: "écale", Score: 2},
+				{Value: "écoles", Score: 1.5},
+			},
+			want: []string{"École", "écoles", "écale"},
+		},
+		{
+			name:   "unknown words keep the spellchecker order",
+			ranker: fakeRanker{},
+			suggestions: []Suggestion{
+				{Value: "maison", Score: 2},
+				{Value: "raisin", Score: 1},
+			},
+			want: []string{"maison", "raisin"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Checker{ranker: tt.ranker}
+			c.rerank([]string{"le"}, tt.suggestions)
+			if got := values(tt.suggestions); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("rerank() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package checker
+
+import (
+	"github.com/axide-dev/axidev-corrige/internal/tokenize"
+)
+
+// Misspelling is a word of a text rejected by its checker
+type Misspelling struct {
+	Token       tokenize.Token
+	Language    string
+	Suggestions []Suggestion
+}
+
+// CheckText checks every word of text, split with the same rules as live
+// typing. The language of each word is detected from the words before it.
+func (d *Detector) CheckText(text string, maxSuggestions int) []Misspelling {
+	var misspellings []Misspelling
+	context := make([]string, 0, d.window)
+
+	for _, token := range tokenize.Scan(text) {
+		word := token.Parts.Word
+		chk := d.CheckerFor(word, context)
+		if result := chk.CheckContext(word, context, maxSuggestions); !result.IsCorrect {
+			misspellings = append(misspellings, Misspelling{
+				Token:       token,
+				Language:    chk.Language().Code,
+				Suggestions: result.Suggestions,
+			})
+		}
+
+		if len(context) == d.window {
+			context = append(context[:0], context[1:]...)
+		}
+		context = append(context, word)
+	}
+	return misspellings
+}
@@ -0,0 +1,75 @@
+package checker
+
+import (
+	"sort"
+
+	"github.com/axide-dev/axidev-corrige/internal/layout"
+
+	spellchecker "github.com/f1monkey/spellchecker/v3"
+)
+
+// neighbourCost is the cost of hitting a key next to the intended one,
+// other edits costing 1
+const neighbourCost = 0.5
+
+// SetLayout makes typing a neighbouring key of the layout a smaller error
+// than other substitutions, so fat-finger typos rank first. nil restores
+// the generic edit distance.
+func (c *Checker) SetLayout(l *layout.Layout) {
+	c.layout = l
+}
+
+// layoutPool is the number of candidates the layout reorders
+const layoutPool = 10
+
+// weighTypos rescores the spellchecker matches for word with the layout,
+// best first. The library's default filter gives each candidate
+// 1/(1+d²) for an edit distance d, times a factor for its frequency and
+// common ends; typoWeight swaps d for the layout-weighted distance.
+func (c *Checker) weighTypos(word string, matches []spellchecker.Match) {
+	if c.layout == nil {
+		return
+	}
+	typed := []rune(word)
+	for i := range matches {
+		matches[i].Score *= typoWeight(c.layout, typed, []rune(matches[i].Value))
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+}
+
+// typoWeight is the ratio between the default filter's distance factor
+// for the plain and the layout-weighted edit distance from typed to word
+func typoWeight(l *layout.Layout, typed, word []rune) float64 {
+	plain := typoDistance(nil, typed, word)
+	weighted := typoDistance(l, typed, word)
+	return (1 + plain*plain) / (1 + weighted*weighted)
+}
+
+// typoDistance is the edit distance from typed to word where substituting
+// a key with its neighbour costs neighbourCost, or the plain edit distance
+// when l is nil
+func typoDistance(l *layout.Layout, typed, word []rune) float64 {
+	prev := make([]float64, len(word)+1)
+	curr := make([]float64, len(word)+1)
+	for j := range prev {
+		prev[j] = float64(j)
+	}
+
+	for i := 1; i <= len(typed); i++ {
+		curr[0] = float64(i)
+		for j := 1; j <= len(word); j++ {
+			sub := 1.0
+			switch {
+			case typed[i-1] == word[j-1]:
+				sub = 0
+			case l != nil && l.Adjacent(typed[i-1], word[j-1]):
+				sub = neighbourCost
+			}
+			curr[j] = min(prev[j-1]+sub, prev[j]+1, curr[j-1]+1)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(word)]
+}
@@ -0,0 +1,97 @@
+package checker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/axide-dev/axidev-corrige/internal/layout"
+)
+
+func TestTypoDistance(t *testing.T) {
+	azerty, _ := layout.Builtin("azerty")
+
+	tests := []struct {
+		l           *layout.Layout
+		typed, word string
+		want        float64
+	}{
+		{azerty, "maison", "maison", 0},
+		{azerty, "maidon", "maison", neighbourCost},
+		{azerty, "maipon", "maison", 1},
+		{azerty, "zerty", "azerty", 1},
+		{azerty, "qzerty", "azerty", neighbourCost},
+		{azerty, "qzertu", "azerty", 2 * neighbourCost},
+		{nil, "maidon", "maison", 1},
+		{nil, "chat", "chien", 3},
+		{nil, "", "abc", 3},
+	}
+	for _, tt := range tests {
+		if got := typoDistance(tt.l, []rune(tt.typed), []rune(tt.word)); got != tt.want {
+			t.Errorf("typoDistance(%q, %q) = %v, want %v", tt.typed, tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestLayoutRanksNeighboursFirst(t *testing.T) {
+	dir := isolateUserData(t)
+	if err := os.WriteFile(filepath.Join(dir, "en.txt"), []byte("uzerty\nazerty\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	c, err := NewChecker("en")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// "q" is next to "a" on AZERTY, far from "u"; both are one edit away
+	plain := scores(c.Suggest("qzerty", 5))
+	if plain["azerty"] == 0 || plain["azerty"] != plain["uzerty"] {
+		t.Fatalf("scores without layout = %v, want azerty and uzerty equal", plain)
+	}
+
+	azerty, _ := layout.Builtin("azerty")
+	c.SetLayout(azerty)
+	suggestions := c.Suggest("qzerty", 5)
+	if len(suggestions) != 2 || suggestions[0].Value != "azerty" || suggestions[0].Score <= suggestions[1].Score {
+		t.Errorf("suggestions on AZERTY = %+v, want azerty first", suggestions)
+	}
+	if got := scores(c.Suggest("zerty", 5)); got["azerty"] == 0 {
+		t.Errorf("suggestions for zerty = %v, want azerty", got)
+	}
+
+	qwerty, _ := layout.Builtin("qwerty")
+	c.SetLayout(qwerty)
+	got := scores(c.Suggest("qzerty", 5))
+	if got["azerty"] <= got["uzerty"] {
+		t.Errorf("scores on QWERTY = %v, want azerty first as q and a are neighbours too", got)
+	}
+	c.SetLayout(nil)
+	if got := scores(c.Suggest("qzerty", 5)); got["azerty"] != got["uzerty"] {
+		t.Errorf("scores after removing the layout = %v", got)
+	}
+}
+
+func TestLayoutFrench(t *testing.T) {
+	isolateUserData(t)
+	c, err := NewChecker("fr")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// "maison" and "raisin" are both one edit from "maisin", but only
+	// "o" is next to "i" on AZERTY
+	plain := scores(c.Suggest("maisin", 3))
+	if plain["maison"] == 0 || plain["maison"] != plain["raisin"] {
+		t.Fatalf("scores without layout = %v, want maison and raisin equal", plain)
+	}
+
+	azerty, _ := layout.Builtin("azerty")
+	c.SetLayout(azerty)
+	suggestions := c.Suggest("maisin", 3)
+	if len(suggestions) != 3 {
+		t.Fatalf("suggestions on AZERTY = %+v, want 3", suggestions)
+	}
+	if suggestions[0].Value != "maison" || suggestions[1].Value != "raisin" {
+		t.Errorf("suggestions on AZERTY = %+v, want maison then raisin", suggestions)
+	}
+}
@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/axide-dev/axidev-corrige/internal/checker"
+)
+
+// stdinName is the file name reported for standard input
+const stdinName = "<stdin>"
+
+// finding is a misspelling as printed by check
+type finding struct {
+	File        string   `json:"file"`
+	Line        int      `json:"line"`
+	Column      int      `json:"column"`
+	Word        string   `json:"word"`
+	Language    string   `json:"language"`
+	Suggestions []string `json:"suggestions"`
+}
+
+// runCheck spell-checks files, or stdin when none or "-" is given, and
+// exits with ExitFindings if any word is misspelled
+func runCheck(env Env, args []string) int {
+	flags := flag.NewFlagSet("check", flag.ContinueOnError)
+	flags.SetOutput(env.Stderr)
+	langs := flags.String("lang", "fr", "comma-separated languages, words are checked against the one detected")
+	format := flags.String("format", "human", "output format: human or json")
+	personal := flags.String("personal", "", "personal dictionary `path` (default: the one used by the overlay)")
+	max := flags.Int("max", 3, "number of suggestions per misspelling")
+	flags.Usage = func() {
+		fmt.Fprintln(env.Stderr, "usage: axidev-corrige check [flags] [file ...]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return ExitOK
+		}
+		return ExitError
+	}
+	if *format != "human" && *format != "json" {
+		fmt.Fprintf(env.Stderr, "check: unknown format %q\n", *format)
+		return ExitError
+	}
+
+	detector, _, err := loadDetector(*langs, *personal)
+	if err != nil {
+		fmt.Fprintf(env.Stderr, "check: %v\n", err)
+		return ExitError
+	}
+
+	files := flags.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+
+	status := ExitOK
+	findings := []finding{}
+	for _, file := range files {
+		name, text, err := readInput(env, file)
+		if err != nil {
+			fmt.Fprintf(env.Stderr, "check: %v\n", err)
+			status = ExitError
+			continue
+		}
+
+		for _, m := range detector.CheckText(text, *max) {
+			findings = append(findings, newFinding(name, m))
+		}
+	}
+
+	if *format == "json" {
+		enc := json.NewEncoder(env.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(findings); err != nil {
+			fmt.Fprintf(env.Stderr, "check: %v\n", err)
+			return ExitError
+		}
+	} else {
+		showLanguage := len(detector.Checkers()) > 1
+		for _, f := range findings {
+			fmt.Fprintln(env.Stdout, f.human(showLanguage))
+		}
+	}
+
+	if status == ExitOK && len(findings) > 0 {
+		status = ExitFindings
+	}
+	return status
+}
+
+// readInput returns the display name and contents of file, "-" being stdin
+func readInput(env Env, file string) (string, string, error) {
+	if file == "-" {
+		data, err := io.ReadAll(env.Stdin)
+		return stdinName, string(data), err
+	}
+	data, err := os.ReadFile(file)
+	return file, string(data), err
+}
+
+func newFinding(file string, m checker.Misspelling) finding {
+	suggestions := make([]string, len(m.Suggestions))
+	for i, s := range m.Suggestions {
+		suggestions[i] = s.Value
+	}
+	return finding{
+		File:        file,
+		Line:        m.Token.Line,
+		Column:      m.Token.Column,
+		Word:        m.Token.Parts.Word,
+		Language:    m.Language,
+		Suggestions: suggestions,
+	}
+}
+
+// human formats the finding as "file:line:col: word → suggestions"
+func (f finding) human(showLanguage bool) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s:%d:%d: %s", f.File, f.Line, f.Column, f.Word)
+	if showLanguage {
+		fmt.Fprintf(&b, " [%s]", f.Language)
+	}
+	if len(f.Suggestions) > 0 {
+		fmt.Fprintf(&b, " → %s", strings.Join(f.Suggestions, ", "))
+	} else {
+		b.WriteString(" (no suggestion)")
+	}
+	return b.String()
+}
@@ -0,0 +1,64 @@
+// Package cli implements the headless subcommands run instead of the GUI
+package cli
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Exit statuses shared by the subcommands
+const (
+	// ExitOK - nothing to report
+	ExitOK = 0
+	// ExitFindings - misspellings were found
+	ExitFindings = 1
+	// ExitError - invalid usage or unreadable input
+	ExitError = 2
+	// ExitNoShutdown - the language client sent exit before shutdown, for
+	// which the protocol requires status 1
+	ExitNoShutdown = 1
+)
+
+// Env holds the standard streams of a command
+type Env struct {
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// command runs a subcommand with its arguments and returns the exit status
+type command func(env Env, args []string) int
+
+// commands maps subcommand names to their implementation
+var commands = map[string]command{
+	"check": runCheck,
+	"fix":   runFix,
+	"freq":  runFreq,
+	"lsp":   runLSP,
+}
+
+// IsCommand returns true if name is a subcommand
+func IsCommand(name string) bool {
+	_, ok := commands[name]
+	return ok
+}
+
+// Commands returns the subcommand names
+func Commands() []string {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Run runs the subcommand named by args[0] and returns the exit status
+func Run(env Env, args []string) int {
+	if len(args) == 0 || !IsCommand(args[0]) {
+		fmt.Fprintf(env.Stderr, "usage: axidev-corrige <command> [flags], commands: %v\n", Commands())
+		return ExitError
+	}
+	return commands[args[0]](env, args[1:])
+}
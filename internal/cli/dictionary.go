@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/axide-dev/axidev-corrige/internal/checker"
+)
+
+// loadDetector loads the comma-separated languages and merges the personal
+// dictionary at path, or at the default location if path is empty
+func loadDetector(langs, path string) (*checker.Detector, *checker.PersonalDictionary, error) {
+	if path == "" {
+		var err error
+		if path, err = checker.DefaultPersonalDictionaryPath(); err != nil {
+			return nil, nil, fmt.Errorf("failed to locate personal dictionary: %w", err)
+		}
+	}
+	personal, err := checker.LoadPersonalDictionary(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load personal dictionary: %w", err)
+	}
+
+	var checkers []*checker.Checker
+	for _, code := range strings.Split(langs, ",") {
+		code = strings.TrimSpace(code)
+		if code == "" {
+			continue
+		}
+		chk, err := checker.NewChecker(code)
+		if err != nil {
+			return nil, nil, err
+		}
+		chk.SetPersonal(personal)
+		checkers = append(checkers, chk)
+	}
+	if len(checkers) == 0 {
+		return nil, nil, fmt.Errorf("no language given")
+	}
+	return checker.NewDetector(checkers, checker.DefaultDetectionWindow), personal, nil
+}
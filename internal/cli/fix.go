@@ -0,0 +1,224 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/axide-dev/axidev-corrige/internal/checker"
+	"github.com/axide-dev/axidev-corrige/internal/fix"
+)
+
+// fixOptions are the flags of the fix command
+type fixOptions struct {
+	minScore    float64
+	write       bool
+	backup      string
+	interactive bool
+}
+
+// runFix applies confident corrections to files. By default a unified diff
+// is printed; -w rewrites the files, keeping a backup. Stdin is corrected
+// to stdout.
+func runFix(env Env, args []string) int {
+	flags := flag.NewFlagSet("fix", flag.ContinueOnError)
+	flags.SetOutput(env.Stderr)
+	langs := flags.String("lang", "fr", "comma-separated languages, words are checked against the one detected")
+	personalPath := flags.String("personal", "", "personal dictionary `path` (default: the one used by the overlay)")
+	var opts fixOptions
+	flags.Float64Var(&opts.minScore, "min-score", checker.DefaultMinScore, "score the best suggestion needs to be applied")
+	flags.BoolVar(&opts.write, "w", false, "rewrite the files instead of printing a diff")
+	flags.StringVar(&opts.backup, "backup", ".bak", "`suffix` of the copy kept by -w, empty for none")
+	flags.BoolVar(&opts.interactive, "i", false, "ask before each correction")
+	flags.Usage = func() {
+		fmt.Fprintln(env.Stderr, "usage: axidev-corrige fix [flags] [file ...]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return ExitOK
+		}
+		return ExitError
+	}
+
+	files := flags.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+	for _, file := range files {
+		if file == "-" && (opts.write || opts.interactive || len(files) > 1) {
+			fmt.Fprintln(env.Stderr, "fix: stdin can only be fixed alone, without -w or -i")
+			return ExitError
+		}
+	}
+
+	detector, personal, err := loadDetector(*langs, *personalPath)
+	if err != nil {
+		fmt.Fprintf(env.Stderr, "fix: %v\n", err)
+		return ExitError
+	}
+
+	f := &fixer{
+		env:      env,
+		opts:     opts,
+		detector: detector,
+		personal: personal,
+		answers:  bufio.NewReader(env.Stdin),
+	}
+
+	status := ExitOK
+	for _, file := range files {
+		changed, err := f.fixFile(file)
+		switch {
+		case errors.Is(err, errQuit):
+			return status
+		case err != nil:
+			fmt.Fprintf(env.Stderr, "fix: %v\n", err)
+			status = ExitError
+		case changed && !opts.write && file != "-" && status == ExitOK:
+			// A diff was printed, like check reporting misspellings
+			status = ExitFindings
+		}
+	}
+	return status
+}
+
+// errQuit stops an interactive session
+var errQuit = errors.New("quit")
+
+// fixer holds the state of a fix run across files
+type fixer struct {
+	env      Env
+	opts     fixOptions
+	detector *checker.Detector
+	personal *checker.PersonalDictionary
+	answers  *bufio.Reader
+}
+
+// fixFile corrects one file and reports whether it changed
+func (f *fixer) fixFile(file string) (bool, error) {
+	name, text, err := readInput(f.env, file)
+	if err != nil {
+		return false, err
+	}
+
+	// Quitting still applies the corrections accepted so far
+	fixes := fix.Plan(f.detector, text, f.opts.minScore)
+	var quit error
+	if f.opts.interactive {
+		if fixes, err = f.confirm(name, fixes); err != nil {
+			if !errors.Is(err, errQuit) {
+				return false, err
+			}
+			quit = err
+		}
+	}
+	fixed := fix.Apply(text, fixes)
+
+	switch {
+	case file == "-":
+		_, err = io.WriteString(f.env.Stdout, fixed)
+	case fixed == text:
+	case f.opts.write:
+		err = writeFixed(file, text, fixed, f.opts.backup)
+		if err == nil {
+			fmt.Fprintf(f.env.Stderr, "%s: %d correction(s)\n", file, len(fixes))
+		}
+	default:
+		_, err = io.WriteString(f.env.Stdout, fix.Diff(name, name, text, fixed))
+	}
+	if err == nil {
+		err = quit
+	}
+	return fixed != text, err
+}
+
+// confirm asks about each fix and returns the accepted ones. Words added
+// to the personal dictionary are no longer proposed.
+func (f *fixer) confirm(name string, fixes []fix.Fix) ([]fix.Fix, error) {
+	var accepted []fix.Fix
+	for _, fx := range fixes {
+		word := fx.Word()
+		if f.personal.Has(word) {
+			continue
+		}
+
+		token := fx.Misspelling.Token
+		for {
+			fmt.Fprintf(f.env.Stderr, "%s:%d:%d: %s → %s  [y]es [n]o [a]dd [q]uit? ",
+				name, token.Line, token.Column, word, fx.Replacement)
+			answer, err := f.answers.ReadString('\n')
+			if err != nil && answer == "" {
+				fmt.Fprintln(f.env.Stderr)
+				return accepted, errQuit
+			}
+
+			switch strings.ToLower(strings.TrimSpace(answer)) {
+			case "y", "yes", "":
+				accepted = append(accepted, fx)
+			case "n", "no":
+			case "a", "add":
+				if err := f.personal.Add(word); err != nil {
+					return accepted, fmt.Errorf("failed to save personal dictionary: %w", err)
+				}
+				for _, chk := range f.detector.Checkers() {
+					chk.Learn(word)
+				}
+			case "q", "quit":
+				return accepted, errQuit
+			default:
+				continue
+			}
+			break
+		}
+	}
+	return accepted, nil
+}
+
+// writeFixed replaces file with fixed, first copying text to file+backup.
+// The fixed text is written to a temporary file next to it and renamed over
+// it, so an interrupted write never leaves file truncated.
+func writeFixed(file, text, fixed, backup string) error {
+	info, err := os.Stat(file)
+	if err != nil {
+		return err
+	}
+	if backup != "" {
+		if err := os.WriteFile(file+backup, []byte(text), info.Mode().Perm()); err != nil {
+			return fmt.Errorf("failed to write backup: %w", err)
+		}
+	}
+
+	// Replace the target of a symbolic link, not the link itself
+	target, err := filepath.EvalSymlinks(file)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(target), "."+filepath.Base(target)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.WriteString(fixed); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(info.Mode().Perm()); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), target)
+}
@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestWriteFixed(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "notes.txt")
+	if err := os.WriteFile(file, []byte("la maisonn\n"), 0o640); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(file, 0o640); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := writeFixed(file, "la maisonn\n", "la maison\n", ".orig"); err != nil {
+		t.Fatal(err)
+	}
+
+	if data, err := os.ReadFile(file); err != nil || string(data) != "la maison\n" {
+		t.Errorf("file = %q, %v", data, err)
+	}
+	if data, err := os.ReadFile(file + ".orig"); err != nil || string(data) != "la maisonn\n" {
+		t.Errorf("backup = %q, %v", data, err)
+	}
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if mode := info.Mode().Perm(); mode != 0o640 {
+			t.Errorf("mode = %v, want 0640", mode)
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		var names []string
+		for _, e := range entries {
+			names = append(names, e.Name())
+		}
+		t.Errorf("directory holds %v, want only the file and its backup", names)
+	}
+}
+
+func TestWriteFixedSymlink(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symbolic links need privileges on Windows")
+	}
+	dir := t.TempDir()
+	target := filepath.Join(dir, "target.txt")
+	link := filepath.Join(dir, "link.txt")
+	if err := os.WriteFile(target, []byte("teh\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(target, link); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := writeFixed(link, "teh\n", "the\n", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	if info, err := os.Lstat(link); err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Errorf("link replaced by a regular file: %v", err)
+	}
+	if data, err := os.ReadFile(target); err != nil || string(data) != "the\n" {
+		t.Errorf("target = %q, %v", data, err)
+	}
+}
+
+func TestWriteFixedMissingFile(t *testing.T) {
+	if err := writeFixed(filepath.Join(t.TempDir(), "missing.txt"), "", "x", ""); err == nil {
+		t.Error("writing a missing file succeeded")
+	}
+}
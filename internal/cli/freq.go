@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/axide-dev/axidev-corrige/internal/checker"
+)
+
+// runFreq counts how often the dictionary words occur in corpus files, or
+// stdin when none or "-" is given, and writes a frequency word list
+func runFreq(env Env, args []string) int {
+	flags := flag.NewFlagSet("freq", flag.ContinueOnError)
+	flags.SetOutput(env.Stderr)
+	lang := flags.String("lang", "fr", "language whose dictionary words are counted")
+	output := flags.String("o", "", "output `path` (default: stdout)")
+	flags.Usage = func() {
+		fmt.Fprintln(env.Stderr, "usage: axidev-corrige freq [flags] [corpus ...]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return ExitOK
+		}
+		return ExitError
+	}
+
+	files := flags.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+	readers := make([]io.Reader, 0, len(files))
+	for _, file := range files {
+		if file == "-" {
+			readers = append(readers, env.Stdin)
+			continue
+		}
+		f, err := os.Open(file)
+		if err != nil {
+			fmt.Fprintf(env.Stderr, "freq: %v\n", err)
+			return ExitError
+		}
+		defer f.Close()
+		readers = append(readers, f)
+	}
+
+	list, err := checker.CountFrequencies(*lang, readers...)
+	if err != nil {
+		fmt.Fprintf(env.Stderr, "freq: %v\n", err)
+		return ExitError
+	}
+
+	if *output == "" {
+		err = checker.WriteFrequencies(env.Stdout, list)
+	} else {
+		err = writeFrequencyFile(*output, list)
+	}
+	if err != nil {
+		fmt.Fprintf(env.Stderr, "freq: %v\n", err)
+		return ExitError
+	}
+	return ExitOK
+}
+
+// writeFrequencyFile writes the frequency word list to path
+func writeFrequencyFile(path string, list []checker.WordFrequency) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := checker.WriteFrequencies(f, list); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
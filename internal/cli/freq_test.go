@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// counts parses the output of freq into word counts
+func counts(t *testing.T, out string) map[string]string {
+	t.Helper()
+	result := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\n"), "\n") {
+		word, count, ok := strings.Cut(line, "\t")
+		if !ok {
+			t.Fatalf("line %q has no tab", line)
+		}
+		result[word] = count
+	}
+	return result
+}
+
+func TestFreqSeparatesFiles(t *testing.T) {
+	// Use the embedded dictionary, not one installed by the user
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_DATA_HOME", "")
+
+	dir := t.TempDir()
+	first := filepath.Join(dir, "a.txt")
+	second := filepath.Join(dir, "b.txt")
+	if err := os.WriteFile(first, []byte("le chien dort\nsous la table"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(second, []byte("maison du chien\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	status := Run(Env{Stdout: &stdout, Stderr: &stderr}, []string{"freq", first, second})
+	if status != ExitOK {
+		t.Fatalf("status %d: %s", status, stderr.String())
+	}
+
+	got := counts(t, stdout.String())
+	want := map[string]string{"chien": "2", "table": "1", "maison": "1", "dort": "1", "abandon": "0"}
+	for word, count := range want {
+		if got[word] != count {
+			t.Errorf("%s counted %q times, want %s", word, got[word], count)
+		}
+	}
+	if _, ok := got["tablemaison"]; ok {
+		t.Error("last word of the first file merged with the next one")
+	}
+}
+
+func TestFreqOutputFile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_DATA_HOME", "")
+
+	output := filepath.Join(t.TempDir(), "fr.txt")
+	var stdout, stderr bytes.Buffer
+	env := Env{Stdin: strings.NewReader("Pour le chien, pour la maison."), Stdout: &stdout, Stderr: &stderr}
+	if status := Run(env, []string{"freq", "-o", output}); status != ExitOK {
+		t.Fatalf("status %d: %s", status, stderr.String())
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("unexpected output on stdout: %q", stdout.String())
+	}
+
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "pour\t2\n") {
+		t.Errorf("most frequent word not first: %q", string(data)[:min(len(data), 40)])
+	}
+}
+
+func TestFreqErrors(t *testing.T) {
+	tests := [][]string{
+		{"freq", "-lang", "xx"},
+		{"freq", filepath.Join(t.TempDir(), "missing.txt")},
+	}
+	for _, args := range tests {
+		var stdout, stderr bytes.Buffer
+		env := Env{Stdin: strings.NewReader(""), Stdout: &stdout, Stderr: &stderr}
+		if status := Run(env, args); status != ExitError {
+			t.Errorf("%q: status %d, want %d", args, status, ExitError)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+
+	"github.com/axide-dev/axidev-corrige/internal/checker"
+	"github.com/axide-dev/axidev-corrige/internal/lsp"
+)
+
+// runLSP serves the Language Server Protocol on stdin and stdout. Logs go
+// to stderr, which editors show in their server output.
+func runLSP(env Env, args []string) int {
+	flags := flag.NewFlagSet("lsp", flag.ContinueOnError)
+	flags.SetOutput(env.Stderr)
+	langs := flags.String("lang", "fr", "comma-separated languages, words are checked against the one detected")
+	personal := flags.String("personal", "", "personal dictionary `path` (default: the one used by the overlay)")
+	flags.Usage = func() {
+		fmt.Fprintln(env.Stderr, "usage: axidev-corrige lsp [flags]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return ExitOK
+		}
+		return ExitError
+	}
+
+	log := slog.New(slog.NewTextHandler(env.Stderr, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	load := func() (*checker.Detector, *checker.PersonalDictionary, error) {
+		return loadDetector(*langs, *personal)
+	}
+
+	err := lsp.NewServer(env.Stdin, env.Stdout, load, log).Run()
+	switch {
+	case errors.Is(err, lsp.ErrNoShutdown):
+		return ExitNoShutdown
+	case err != nil:
+		log.Error("Language server stopped", "err", err)
+		return ExitError
+	}
+	return ExitOK
+}
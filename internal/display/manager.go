@@ -11,6 +11,12 @@ import (
 type Update struct {
 	Text  string
 	State string
+	// Word is the flagged word the overlay can act on, if any
+	Word string
+	// Hint is a short help line shown under the text, if any
+	Hint string
+	// Suggestions are listed with their pick number, if any
+	Suggestions []string
 }
 
 // State constants for display states
@@ -21,6 +27,8 @@ const (
 	StateIncorrect  = "incorrect"
 	StateSuggestion = "suggestion"
 	StateCorrecting = "correcting"
+	StatePaused     = "paused"
+	StateGrammar    = "grammar"
 )
 
 // Manager handles UI display updates
@@ -67,9 +75,12 @@ func (m *Manager) processUpdates() {
 		m.mu.RUnlock()
 
 		if ctx != nil {
-			runtime.EventsEmit(ctx, "updateText", map[string]string{
-				"text":  update.Text,
-				"state": update.State,
+			runtime.EventsEmit(ctx, "updateText", map[string]any{
+				"text":        update.Text,
+				"state":       update.State,
+				"word":        update.Word,
+				"hint":        update.Hint,
+				"suggestions": update.Suggestions,
 			})
 		}
 	}
@@ -77,6 +88,11 @@ func (m *Manager) processUpdates() {
 
 // Send sends an update to the display (non-blocking)
 func (m *Manager) Send(text, state string) {
+	m.SendUpdate(Update{Text: text, State: state})
+}
+
+// SendUpdate sends an Update struct to the display (non-blocking)
+func (m *Manager) SendUpdate(update Update) {
 	m.mu.RLock()
 	running := m.running
 	m.mu.RUnlock()
@@ -86,17 +102,12 @@ func (m *Manager) Send(text, state string) {
 	}
 
 	select {
-	case m.updateChan <- Update{Text: text, State: state}:
+	case m.updateChan <- update:
 	default:
 		// Channel full, drop update
 	}
 }
 
-// SendUpdate sends an Update struct to the display
-func (m *Manager) SendUpdate(update Update) {
-	m.Send(update.Text, update.State)
-}
-
 // Waiting sends a waiting state update
 func (m *Manager) Waiting() {
 	m.Send("Waiting...", StateWaiting)
@@ -0,0 +1,154 @@
+package fix
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines around each hunk
+const diffContext = 3
+
+// opKind is an edit of a line diff
+type opKind byte
+
+const (
+	opEqual  opKind = ' '
+	opDelete opKind = '-'
+	opInsert opKind = '+'
+)
+
+type diffOp struct {
+	kind opKind
+	line string
+}
+
+// Diff returns a unified diff turning before into after, labelled with
+// the given file names, or "" if they are equal
+func Diff(beforeName, afterName, before, after string) string {
+	if before == after {
+		return ""
+	}
+	ops := diffLines(splitLines(before), splitLines(after))
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", beforeName, afterName)
+
+	// Walk the edits, emitting hunks of changes with surrounding context
+	oldLine, newLine := 1, 1
+	for i := 0; i < len(ops); {
+		if ops[i].kind == opEqual {
+			i++
+			oldLine++
+			newLine++
+			continue
+		}
+
+		// Extend the hunk while changes are closer than twice the context
+		start := max(i-diffContext, 0)
+		for start < i && ops[start].kind != opEqual {
+			start++
+		}
+		end := i
+		for end < len(ops) {
+			if ops[end].kind != opEqual {
+				end++
+				continue
+			}
+			next := end
+			for next < len(ops) && ops[next].kind == opEqual {
+				next++
+			}
+			if next == len(ops) || next-end > 2*diffContext {
+				end = min(end+diffContext, len(ops))
+				break
+			}
+			end = next
+		}
+
+		hunkOld, hunkNew := oldLine-(i-start), newLine-(i-start)
+		var oldCount, newCount int
+		var body strings.Builder
+		for _, op := range ops[start:end] {
+			switch op.kind {
+			case opEqual:
+				oldCount++
+				newCount++
+			case opDelete:
+				oldCount++
+			case opInsert:
+				newCount++
+			}
+			body.WriteByte(byte(op.kind))
+			body.WriteString(op.line)
+			if !strings.HasSuffix(op.line, "\n") {
+				body.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+		fmt.Fprintf(&b, "@@ -%s +%s @@\n", hunkRange(hunkOld, oldCount), hunkRange(hunkNew, newCount))
+		b.WriteString(body.String())
+
+		for _, op := range ops[i:end] {
+			if op.kind != opInsert {
+				oldLine++
+			}
+			if op.kind != opDelete {
+				newLine++
+			}
+		}
+		i = end
+	}
+	return b.String()
+}
+
+// hunkRange formats the start and length of a hunk side
+func hunkRange(start, count int) string {
+	if count == 0 {
+		start--
+	}
+	if count == 1 {
+		return fmt.Sprint(start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
+
+// splitLines splits text after each newline, keeping them
+func splitLines(text string) []string {
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffLines pairs the lines of a and b by position, which is exact for
+// fixes since they replace words without adding or removing lines, and
+// keeps memory linear in the size of the texts. Changed lines in a row are
+// reported as deletions followed by insertions; surplus lines of the longer
+// side join the last run.
+func diffLines(a, b []string) []diffOp {
+	ops := make([]diffOp, 0, len(a)+len(b))
+	n := min(len(a), len(b))
+	for i := 0; i < len(a) || i < len(b); {
+		if i < n && a[i] == b[i] {
+			ops = append(ops, diffOp{opEqual, a[i]})
+			i++
+			continue
+		}
+
+		end := i
+		for end < n && a[end] != b[end] {
+			end++
+		}
+		if end == n {
+			end = max(len(a), len(b))
+		}
+		for _, line := range a[i:min(end, len(a))] {
+			ops = append(ops, diffOp{opDelete, line})
+		}
+		for _, line := range b[i:min(end, len(b))] {
+			ops = append(ops, diffOp{opInsert, line})
+		}
+		i = end
+	}
+	return ops
+}
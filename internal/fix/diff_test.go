@@ -0,0 +1,85 @@
+package fix
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name          string
+		before, after string
+		want          string
+	}{
+		{"equal", "le chien\n", "le chien\n", ""},
+		{
+			"single line",
+			"le chein\n", "le chien\n",
+			"--- f\n+++ f\n@@ -1 +1 @@\n-le chein\n+le chien\n",
+		},
+		{
+			"context",
+			"a\nb\nc\nd\nmaisin\ne\nf\ng\nh\n", "a\nb\nc\nd\nmaison\ne\nf\ng\nh\n",
+			"--- f\n+++ f\n@@ -2,7 +2,7 @@\n b\n c\n d\n-maisin\n+maison\n e\n f\n g\n",
+		},
+		{
+			"consecutive lines",
+			"x\nle chein\nla maisin\ny\n", "x\nle chien\nla maison\ny\n",
+			"--- f\n+++ f\n@@ -1,4 +1,4 @@\n x\n-le chein\n-la maisin\n+le chien\n+la maison\n y\n",
+		},
+		{
+			"no newline at end",
+			"a\nchein", "a\nchien",
+			"--- f\n+++ f\n@@ -1,2 +1,2 @@\n a\n-chein\n\\ No newline at end of file\n+chien\n\\ No newline at end of file\n",
+		},
+		{
+			"nearby changes share a hunk",
+			"chein\n1\n2\n3\n4\nchein\n", "chien\n1\n2\n3\n4\nchien\n",
+			"--- f\n+++ f\n@@ -1,6 +1,6 @@\n-chein\n+chien\n 1\n 2\n 3\n 4\n-chein\n+chien\n",
+		},
+		{
+			"added lines",
+			"a\n", "a\nb\nc\n",
+			"--- f\n+++ f\n@@ -1 +1,3 @@\n a\n+b\n+c\n",
+		},
+		{
+			"from empty",
+			"", "a\n",
+			"--- f\n+++ f\n@@ -0,0 +1 @@\n+a\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Diff("f", "f", tt.before, tt.after); got != tt.want {
+				t.Errorf("Diff() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffLargeInput(t *testing.T) {
+	const lines = 200000
+	var before, after strings.Builder
+	for i := range lines {
+		line := fmt.Sprintf("ligne %d du texte\n", i)
+		before.WriteString(line)
+		if i == 0 || i == lines-1 {
+			line = fmt.Sprintf("ligne %d du textte\n", i)
+		}
+		after.WriteString(line)
+	}
+
+	start := time.Now()
+	got := Diff("f", "f", before.String(), after.String())
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Diff took %v", elapsed)
+	}
+	if hunks := strings.Count(got, "\n@@ "); hunks != 2 {
+		t.Errorf("got %d hunks, want 2:\n%s", hunks, got)
+	}
+	if !strings.Contains(got, fmt.Sprintf("@@ -%d,4 +%d,4 @@\n", lines-3, lines-3)) {
+		t.Errorf("wrong last hunk:\n%s", got)
+	}
+}
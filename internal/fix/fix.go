@@ -0,0 +1,69 @@
+// Package fix applies confident spelling corrections to whole texts
+package fix
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/axide-dev/axidev-corrige/internal/checker"
+)
+
+// Fix replaces a misspelled word of a text with its best suggestion
+type Fix struct {
+	Misspelling checker.Misspelling
+	// Replacement is the suggestion replacing the word
+	Replacement string
+	// Score is the suggestion score
+	Score float64
+}
+
+// Start returns the byte offset of the word in the text
+func (f Fix) Start() int {
+	return f.Misspelling.Token.Offset
+}
+
+// End returns the byte offset just after the word
+func (f Fix) End() int {
+	return f.Start() + len(f.Misspelling.Token.Parts.Word)
+}
+
+// Word returns the misspelled word
+func (f Fix) Word() string {
+	return f.Misspelling.Token.Parts.Word
+}
+
+// Plan returns the fixes for the misspellings of text whose best
+// suggestion scores at least minScore, the threshold used for live
+// auto-correction
+func Plan(d *checker.Detector, text string, minScore float64) []Fix {
+	var fixes []Fix
+	for _, m := range d.CheckText(text, 1) {
+		result := checker.Result{Original: m.Token.Parts.Word, Suggestions: m.Suggestions}
+		if best, ok := result.AutoCorrection(minScore); ok {
+			fixes = append(fixes, Fix{Misspelling: m, Replacement: best.Value, Score: best.Score})
+		}
+	}
+	return fixes
+}
+
+// Apply returns text with fixes applied. Fixes must come from Plan on the
+// same text; overlapping ones are skipped.
+func Apply(text string, fixes []Fix) string {
+	sorted := make([]Fix, len(fixes))
+	copy(sorted, fixes)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Start() < sorted[j].Start() })
+
+	var b strings.Builder
+	b.Grow(len(text))
+	pos := 0
+	for _, f := range sorted {
+		if f.Start() < pos || f.End() > len(text) {
+			continue
+		}
+		b.WriteString(text[pos:f.Start()])
+		b.WriteString(f.Replacement)
+		pos = f.End()
+	}
+	b.WriteString(text[pos:])
+	return b.String()
+}
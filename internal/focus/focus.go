@@ -0,0 +1,127 @@
+// Package focus reports the application owning the focused window, so
+// corrections can be disabled or limited per application.
+package focus
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrUnsupported is returned when the focused window cannot be queried on
+// this platform or session
+var ErrUnsupported = errors.New("focused window detection is not supported")
+
+// Window describes the focused window
+type Window struct {
+	// App is the process name, e.g. "kitty"
+	App string
+	// Class is the X11 WM_CLASS or Wayland app_id, e.g. "org.gnome.Terminal"
+	Class string
+	// Title is the window title
+	Title string
+}
+
+// IsZero returns true if no window is focused
+func (w Window) IsZero() bool {
+	return w == Window{}
+}
+
+// SameApp returns true if both windows belong to the same application.
+// Titles are ignored as editors, browsers and terminals change theirs while
+// typing.
+func (w Window) SameApp(other Window) bool {
+	return w.App == other.App && w.Class == other.Class
+}
+
+// Name returns the most readable identifier of the window
+func (w Window) Name() string {
+	switch {
+	case w.App != "":
+		return w.App
+	case w.Class != "":
+		return w.Class
+	default:
+		return w.Title
+	}
+}
+
+// Provider reports the focused window
+type Provider interface {
+	Focused() (Window, error)
+}
+
+// NewProvider returns the provider for the current platform and session.
+// Its Focused method returns ErrUnsupported when nothing can be queried.
+func NewProvider() Provider {
+	if p := platformProvider(); p != nil {
+		return p
+	}
+	return unsupported{}
+}
+
+type unsupported struct{}
+
+func (unsupported) Focused() (Window, error) {
+	return Window{}, ErrUnsupported
+}
+
+// cached reuses the last answer of a provider for a while, since querying
+// the window system on every key press is too slow
+type cached struct {
+	provider Provider
+	ttl      time.Duration
+
+	mu     sync.Mutex
+	at     time.Time
+	window Window
+	err    error
+}
+
+// Cached wraps p so it is queried at most once per ttl
+func Cached(p Provider, ttl time.Duration) Provider {
+	return &cached{provider: p, ttl: ttl}
+}
+
+func (c *cached) Focused() (Window, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.at.IsZero() || time.Since(c.at) >= c.ttl {
+		c.window, c.err = c.provider.Focused()
+		c.at = time.Now()
+	}
+	return c.window, c.err
+}
+
+// Fake is a Provider returning a window set by the caller, for tests
+type Fake struct {
+	mu     sync.Mutex
+	window Window
+	err    error
+}
+
+// Set changes the focused window and clears any error
+func (f *Fake) Set(w Window) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.window = w
+	f.err = nil
+}
+
+// SetError makes Focused fail with err
+func (f *Fake) SetError(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.err = err
+}
+
+// Focused returns the window given to Set
+func (f *Fake) Focused() (Window, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return Window{}, f.err
+	}
+	return f.window, nil
+}
@@ -0,0 +1,184 @@
+//go:build linux
+
+package focus
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// platformProvider picks the compositor or X11 tool able to report the
+// focused window, or nil if none is available
+func platformProvider() Provider {
+	available := func(tool string) bool {
+		_, err := exec.LookPath(tool)
+		return err == nil
+	}
+
+	switch {
+	case os.Getenv("HYPRLAND_INSTANCE_SIGNATURE") != "" && available("hyprctl"):
+		return hyprland{}
+	case os.Getenv("SWAYSOCK") != "" && available("swaymsg"):
+		return sway{}
+	case os.Getenv("DISPLAY") != "" && available("xprop"):
+		// Under other Wayland compositors only XWayland windows are seen
+		return x11{}
+	default:
+		return nil
+	}
+}
+
+// x11 queries the EWMH active window with xprop
+type x11 struct{}
+
+func (x11) Focused() (Window, error) {
+	out, err := exec.Command("xprop", "-root", "_NET_ACTIVE_WINDOW").Output()
+	if err != nil {
+		return Window{}, fmt.Errorf("xprop: %w", err)
+	}
+	// _NET_ACTIVE_WINDOW(WINDOW): window id # 0x3a00007
+	fields := strings.Fields(string(out))
+	if len(fields) == 0 {
+		return Window{}, fmt.Errorf("xprop: unexpected output %q", out)
+	}
+	id := fields[len(fields)-1]
+	if id == "0x0" {
+		return Window{}, nil
+	}
+
+	out, err = exec.Command("xprop", "-id", id, "WM_CLASS", "_NET_WM_NAME", "_NET_WM_PID").Output()
+	if err != nil {
+		return Window{}, fmt.Errorf("xprop: %w", err)
+	}
+
+	var w Window
+	for _, line := range strings.Split(string(out), "\n") {
+		name, value, ok := strings.Cut(line, " = ")
+		if !ok {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(name, "WM_CLASS"):
+			// WM_CLASS(STRING) = "instance", "Class"
+			if values := xpropStrings(value); len(values) > 0 {
+				w.Class = values[len(values)-1]
+			}
+		case strings.HasPrefix(name, "_NET_WM_NAME"):
+			if values := xpropStrings(value); len(values) > 0 {
+				w.Title = values[0]
+			}
+		case strings.HasPrefix(name, "_NET_WM_PID"):
+			if pid, err := strconv.Atoi(strings.TrimSpace(value)); err == nil {
+				w.App = processName(pid)
+			}
+		}
+	}
+	return w, nil
+}
+
+// xpropStrings parses a list of quoted xprop values
+func xpropStrings(value string) []string {
+	var values []string
+	for {
+		value = strings.TrimLeft(value, ", ")
+		quoted, err := strconv.QuotedPrefix(value)
+		if err != nil {
+			return values
+		}
+		s, err := strconv.Unquote(quoted)
+		if err != nil {
+			return values
+		}
+		values = append(values, s)
+		value = value[len(quoted):]
+	}
+}
+
+// hyprland queries the active window with hyprctl
+type hyprland struct{}
+
+func (hyprland) Focused() (Window, error) {
+	out, err := exec.Command("hyprctl", "activewindow", "-j").Output()
+	if err != nil {
+		return Window{}, fmt.Errorf("hyprctl: %w", err)
+	}
+
+	var active struct {
+		Class string `json:"class"`
+		Title string `json:"title"`
+		PID   int    `json:"pid"`
+	}
+	if err := json.Unmarshal(out, &active); err != nil {
+		return Window{}, fmt.Errorf("hyprctl: %w", err)
+	}
+	return Window{App: processName(active.PID), Class: active.Class, Title: active.Title}, nil
+}
+
+// sway finds the focused node in the swaymsg tree
+type sway struct{}
+
+// swayNode is the part of a sway tree node needed to find the focused one
+type swayNode struct {
+	Focused          bool   `json:"focused"`
+	Name             string `json:"name"`
+	AppID            string `json:"app_id"`
+	PID              int    `json:"pid"`
+	WindowProperties struct {
+		Class string `json:"class"`
+	} `json:"window_properties"`
+	Nodes         []swayNode `json:"nodes"`
+	FloatingNodes []swayNode `json:"floating_nodes"`
+}
+
+func (sway) Focused() (Window, error) {
+	out, err := exec.Command("swaymsg", "-t", "get_tree", "-r").Output()
+	if err != nil {
+		return Window{}, fmt.Errorf("swaymsg: %w", err)
+	}
+
+	var root swayNode
+	if err := json.Unmarshal(out, &root); err != nil {
+		return Window{}, fmt.Errorf("swaymsg: %w", err)
+	}
+
+	node := root.focused()
+	if node == nil || node.PID == 0 {
+		// A workspace or output is focused, not a window
+		return Window{}, nil
+	}
+	class := node.AppID
+	if class == "" {
+		class = node.WindowProperties.Class
+	}
+	return Window{App: processName(node.PID), Class: class, Title: node.Name}, nil
+}
+
+func (n *swayNode) focused() *swayNode {
+	if n.Focused {
+		return n
+	}
+	for _, children := range [][]swayNode{n.Nodes, n.FloatingNodes} {
+		for i := range children {
+			if f := children[i].focused(); f != nil {
+				return f
+			}
+		}
+	}
+	return nil
+}
+
+// processName returns the command name of pid, or "" if unknown
+func processName(pid int) string {
+	if pid <= 0 {
+		return ""
+	}
+	comm, err := os.ReadFile(fmt.Sprintf("/proc/%d/comm", pid))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(comm))
+}
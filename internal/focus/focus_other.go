@@ -0,0 +1,8 @@
+//go:build !linux
+
+package focus
+
+// platformProvider returns nil: only Linux window systems are supported
+func platformProvider() Provider {
+	return nil
+}
@@ -0,0 +1,119 @@
+package focus
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// Action is what happens to input typed in a matching application
+type Action string
+
+const (
+	// ActionDefault applies the configured correction mode
+	ActionDefault Action = "default"
+	// ActionSuggest shows suggestions but never types replacements
+	ActionSuggest Action = "suggest"
+	// ActionIgnore does not track input at all
+	ActionIgnore Action = "ignore"
+)
+
+// Valid returns true if the action is one of the known actions
+func (a Action) Valid() bool {
+	switch a {
+	case ActionDefault, ActionSuggest, ActionIgnore:
+		return true
+	default:
+		return false
+	}
+}
+
+// titlePrefix makes a rule match the window title instead of its
+// application
+const titlePrefix = "title:"
+
+// Rule applies an action to the windows matching a glob pattern. The
+// pattern is matched case-insensitively against the application name and
+// window class, or against the title when prefixed with "title:".
+type Rule struct {
+	Match  string `json:"match"`
+	Action Action `json:"action"`
+}
+
+// Validate reports an invalid pattern or action
+func (r Rule) Validate() error {
+	if strings.TrimPrefix(r.Match, titlePrefix) == "" {
+		return fmt.Errorf("empty match pattern")
+	}
+	if _, err := path.Match(r.pattern(), ""); err != nil {
+		return fmt.Errorf("invalid match pattern %q: %w", r.Match, err)
+	}
+	if !r.Action.Valid() {
+		return fmt.Errorf("action for %q must be one of default, suggest, ignore, got %q", r.Match, r.Action)
+	}
+	return nil
+}
+
+// Matches returns true if the rule applies to w
+func (r Rule) Matches(w Window) bool {
+	candidates := []string{w.App, w.Class}
+	if strings.HasPrefix(r.Match, titlePrefix) {
+		candidates = []string{w.Title}
+	}
+
+	pattern := r.pattern()
+	for _, c := range candidates {
+		if c == "" {
+			continue
+		}
+		if ok, _ := path.Match(pattern, strings.ToLower(c)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (r Rule) pattern() string {
+	return strings.ToLower(strings.TrimPrefix(r.Match, titlePrefix))
+}
+
+// ActionFor returns the action of the first rule matching w, or
+// ActionDefault
+func ActionFor(rules []Rule, w Window) Action {
+	if w.IsZero() {
+		return ActionDefault
+	}
+	for _, r := range rules {
+		if r.Matches(w) {
+			return r.Action
+		}
+	}
+	return ActionDefault
+}
+
+// DefaultRules ignores terminals and password managers, where typed text is
+// commands or secrets, and keeps code editors to suggestions
+func DefaultRules() []Rule {
+	var rules []Rule
+	add := func(action Action, patterns ...string) {
+		for _, p := range patterns {
+			rules = append(rules, Rule{Match: p, Action: action})
+		}
+	}
+
+	// Password managers and PIN prompts
+	add(ActionIgnore,
+		"*keepass*", "1password", "bitwarden", "*pinentry*", "seahorse", "kwalletmanager*",
+	)
+	// Terminals
+	add(ActionIgnore,
+		"*terminal*", "*term", "konsole", "alacritty", "kitty", "foot", "wezterm*",
+		"tilix", "terminator", "ghostty", "iterm2", "warp",
+	)
+	// Code editors and IDEs
+	add(ActionSuggest,
+		"code", "code-oss", "codium", "vscodium", "jetbrains-*", "sublime_text", "zed",
+		"emacs", "*vim", "kate",
+	)
+	return rules
+}
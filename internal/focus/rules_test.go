@@ -0,0 +1,102 @@
+package focus
+
+import "testing"
+
+func TestDefaultRules(t *testing.T) {
+	tests := []struct {
+		window Window
+		want   Action
+	}{
+		{Window{App: "kitty"}, ActionIgnore},
+		{Window{Class: "org.gnome.Terminal"}, ActionIgnore},
+		{Window{App: "xterm"}, ActionIgnore},
+		{Window{App: "KeePassXC"}, ActionIgnore},
+		{Window{App: "pinentry-gtk-2"}, ActionIgnore},
+		{Window{App: "wezterm-gui"}, ActionIgnore},
+		{Window{App: "nvim"}, ActionSuggest},
+		{Window{App: "code"}, ActionSuggest},
+		{Window{Class: "jetbrains-idea"}, ActionSuggest},
+		{Window{App: "firefox", Title: "Terminal - Wikipédia"}, ActionDefault},
+		{Window{App: "codeblocks"}, ActionDefault},
+		{Window{App: "libreoffice-writer"}, ActionDefault},
+		{Window{Title: "kitty"}, ActionDefault},
+		{Window{}, ActionDefault},
+	}
+	rules := DefaultRules()
+	for _, tt := range tests {
+		if got := ActionFor(rules, tt.window); got != tt.want {
+			t.Errorf("ActionFor(%+v) = %s, want %s", tt.window, got, tt.want)
+		}
+	}
+}
+
+func TestRuleMatches(t *testing.T) {
+	tests := []struct {
+		match  string
+		window Window
+		want   bool
+	}{
+		{"firefox", Window{App: "Firefox"}, true},
+		{"FIREFOX", Window{App: "firefox"}, true},
+		{"fire*", Window{Class: "firefox-esr"}, true},
+		{"fire?ox", Window{App: "firefox"}, true},
+		{"[fg]irefox", Window{App: "girefox"}, true},
+		{"firefox", Window{App: "firefox-esr"}, false},
+		{"title:*password*", Window{App: "firefox", Title: "Enter Password - Site"}, true},
+		{"title:*password*", Window{App: "password", Title: "Vault"}, false},
+		{"*password*", Window{Title: "Password"}, false},
+		{"*", Window{}, false},
+	}
+	for _, tt := range tests {
+		if got := (Rule{Match: tt.match, Action: ActionIgnore}).Matches(tt.window); got != tt.want {
+			t.Errorf("%q Matches(%+v) = %v, want %v", tt.match, tt.window, got, tt.want)
+		}
+	}
+}
+
+func TestActionForFirstMatchWins(t *testing.T) {
+	rules := []Rule{
+		{Match: "title:*secret*", Action: ActionIgnore},
+		{Match: "firefox", Action: ActionSuggest},
+		{Match: "*", Action: ActionIgnore},
+	}
+	if got := ActionFor(rules, Window{App: "firefox", Title: "Top secret"}); got != ActionIgnore {
+		t.Errorf("title rule: got %s", got)
+	}
+	if got := ActionFor(rules, Window{App: "firefox", Title: "Docs"}); got != ActionSuggest {
+		t.Errorf("app rule: got %s", got)
+	}
+}
+
+func TestRuleValidate(t *testing.T) {
+	valid := []Rule{
+		{Match: "code", Action: ActionSuggest},
+		{Match: "title:*password*", Action: ActionIgnore},
+	}
+	for _, r := range valid {
+		if err := r.Validate(); err != nil {
+			t.Errorf("%+v: %v", r, err)
+		}
+	}
+	invalid := []Rule{
+		{Match: "", Action: ActionIgnore},
+		{Match: "title:", Action: ActionIgnore},
+		{Match: "[code", Action: ActionIgnore},
+		{Match: "code", Action: "block"},
+	}
+	for _, r := range invalid {
+		if err := r.Validate(); err == nil {
+			t.Errorf("%+v accepted", r)
+		}
+	}
+}
+
+func TestSameApp(t *testing.T) {
+	a := Window{App: "code", Class: "Code", Title: "main.go - project"}
+	if !a.SameApp(Window{App: "code", Class: "Code", Title: "● main.go - project"}) {
+		t.Error("a title change is seen as another application")
+	}
+	if a.SameApp(Window{App: "firefox", Class: "firefox", Title: a.Title}) {
+		t.Error("another application with the same title is seen as the same")
+	}
+}
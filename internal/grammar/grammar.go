@@ -0,0 +1,158 @@
+// Package grammar finds common French grammar mistakes that word-level
+// spell-checking accepts: agreement after determiners, infinitives used
+// for past participles and homophones such as a/à, ou/où and ses/ces.
+package grammar
+
+import (
+	"strings"
+
+	"github.com/axide-dev/axidev-corrige/internal/checker"
+	"github.com/axide-dev/axidev-corrige/internal/tokenize"
+	"github.com/axide-dev/axidev-corrige/internal/writing"
+)
+
+// Lexicon tells whether a word exists, used to pick rewrites that do
+type Lexicon interface {
+	IsCorrect(word string) bool
+}
+
+// Issue is a grammar mistake over consecutive words
+type Issue struct {
+	// Start and End are the indexes of the words involved, End excluded
+	Start, End int
+	// Rule identifies the rule that found the issue
+	Rule string
+	// Message explains the mistake
+	Message string
+	// Replacement holds the rewritten words, one per word of the span
+	Replacement []string
+}
+
+// Rewrite returns the text of the span in words and the same text with
+// the replacement applied, separators between the words kept
+func (i Issue) Rewrite(words []writing.Word) (before, after string) {
+	var b, a strings.Builder
+	for k := i.Start; k < i.End; k++ {
+		b.WriteString(words[k].Text)
+		a.WriteString(i.Replacement[k-i.Start])
+		if k < i.End-1 {
+			b.WriteString(words[k].Separator)
+			a.WriteString(words[k].Separator)
+		}
+	}
+	return b.String(), a.String()
+}
+
+// token is a word of a sentence split from its elision
+type token struct {
+	tokenize.Parts
+	// lower is the lowercase word without elision
+	lower string
+	// index is the position of the word in the checked words
+	index int
+	// joined is true if only whitespace separates it from the previous word
+	joined bool
+}
+
+// rewrite returns the token text with its word replaced, following the
+// capitalization of the typed word
+func (t token) rewrite(word string) string {
+	return t.Join(checker.ApplyCase(word, checker.CaseOf(t.Word)))
+}
+
+// text returns the token as typed
+func (t token) text() string {
+	return t.Join(t.Word)
+}
+
+// rule looks for an issue ending at the token at position i of a sentence,
+// spanning the words it relied on
+type rule func(sentence []token, i int, lex Lexicon) (Issue, bool)
+
+var rules = []rule{
+	pluralAgreement,
+	genderAgreement,
+	pastParticiple,
+	prepositionA,
+	relativeOu,
+	demonstrativeCes,
+}
+
+// Check returns the issues found in the completed words, sorted by the
+// position where they end. Rules never look across sentence ends.
+func Check(words []writing.Word, lex Lexicon) []Issue {
+	var issues []Issue
+	for _, sentence := range sentences(words) {
+		for i := range sentence {
+			for _, r := range rules {
+				if issue, ok := r(sentence, i, lex); ok {
+					issues = append(issues, issue)
+				}
+			}
+		}
+	}
+	return issues
+}
+
+// sentences splits words into sentences of tokens at sentence-ending
+// punctuation and line breaks
+func sentences(words []writing.Word) [][]token {
+	var result [][]token
+	var current []token
+	joined := false
+	for i, w := range words {
+		parts := tokenize.Split(w.Text)
+		current = append(current, token{
+			Parts:  parts,
+			lower:  strings.ToLower(parts.Word),
+			index:  i,
+			joined: joined,
+		})
+
+		separator := w.Separator
+		joined = strings.TrimSpace(separator) == ""
+		if strings.ContainsAny(separator, ".!?…\n\r") {
+			result = append(result, current)
+			current = nil
+			joined = false
+		}
+	}
+	if len(current) > 0 {
+		result = append(result, current)
+	}
+	return result
+}
+
+// previous returns the token before position i if only whitespace
+// separates them
+func previous(sentence []token, i int) (token, bool) {
+	if i == 0 || !sentence[i].joined {
+		return token{}, false
+	}
+	return sentence[i-1], true
+}
+
+// issue builds an issue replacing the given tokens of a sentence
+func issue(name, message string, tokens []token, replacement ...string) Issue {
+	return Issue{
+		Start:       tokens[0].index,
+		End:         tokens[len(tokens)-1].index + 1,
+		Rule:        name,
+		Message:     message,
+		Replacement: replacement,
+	}
+}
+
+// isWord returns true if the token is a plain lowercase word
+func (t token) isWord() bool {
+	return t.Word != "" && tokenize.Checkable(t.Word) && checker.CaseOf(t.Word) == checker.CaseLower
+}
+
+func in(word string, list ...string) bool {
+	for _, w := range list {
+		if word == w {
+			return true
+		}
+	}
+	return false
+}
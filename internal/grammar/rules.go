@@ -0,0 +1,331 @@
+package grammar
+
+import (
+	"strings"
+
+	"github.com/axide-dev/axidev-corrige/internal/checker"
+	"github.com/axide-dev/axidev-corrige/internal/tokenize"
+)
+
+// subjects are the pronouns after which "le", "la" and "les" are object
+// pronouns rather than determiners
+var subjects = []string{"je", "tu", "il", "elle", "on", "nous", "vous", "ils", "elles", "ne", "qui"}
+
+// subjectAt returns true if the token at position j of a sentence may be
+// the subject of a verb, so "le", "la" and "les" after it are object
+// pronouns: a subject pronoun, or a name as in "Paul les aime"
+func subjectAt(sentence []token, j int, lex Lexicon) bool {
+	t := sentence[j]
+	if in(t.lower, subjects...) {
+		return true
+	}
+	if t.Prefix != "" || !tokenize.Checkable(t.Word) || checker.CaseOf(t.Word) == checker.CaseLower {
+		return false
+	}
+	// A capital inside a sentence marks a name. At its start, only a word
+	// unknown in lowercase is one: "Paul les aime", not "Dans les maison".
+	return j > 0 || lex == nil || !lex.IsCorrect(t.lower)
+}
+
+// pluralDeterminers are always followed by a plural noun
+var pluralDeterminers = []string{
+	"les", "des", "ces", "mes", "tes", "ses", "nos", "vos", "leurs",
+	"plusieurs", "quelques", "certains", "certaines", "différents", "divers",
+}
+
+// invariables may follow a plural determiner without taking an s
+var invariables = []string{
+	"deux", "trois", "quatre", "cinq", "six", "sept", "huit", "neuf", "dix",
+	"onze", "douze", "treize", "quatorze", "quinze", "seize", "vingt",
+	"trente", "quarante", "cinquante", "soixante", "cent", "mille",
+	"plus", "moins", "tout", "tous", "bien", "non", "mêmes", "autres",
+}
+
+// pluralAgreement flags a singular noun after a plural determiner:
+// "les maison" → "les maisons"
+func pluralAgreement(sentence []token, i int, lex Lexicon) (Issue, bool) {
+	noun := sentence[i]
+	det, ok := previous(sentence, i)
+	if lex == nil || !ok || det.Prefix != "" || !in(det.lower, pluralDeterminers...) || !noun.isWord() || noun.Prefix != "" {
+		return Issue{}, false
+	}
+	if strings.HasSuffix(noun.lower, "s") || strings.HasSuffix(noun.lower, "x") || strings.HasSuffix(noun.lower, "z") ||
+		in(noun.lower, invariables...) || !lex.IsCorrect(noun.lower) {
+		return Issue{}, false
+	}
+	if det.lower == "les" {
+		// "je les mange", "Paul les aime": "les" is a pronoun
+		if _, ok := previous(sentence, i-1); ok && subjectAt(sentence, i-2, lex) {
+			return Issue{}, false
+		}
+		if strings.HasSuffix(noun.lower, "er") || strings.HasSuffix(noun.lower, "ir") {
+			return Issue{}, false
+		}
+	}
+
+	plural, ok := pluralOf(noun.lower, lex)
+	if !ok {
+		return Issue{}, false
+	}
+	return issue("plural-agreement", "Plural agreement after “"+det.Word+"”",
+		sentence[i:i+1], noun.rewrite(plural)), true
+}
+
+// pluralOf returns the plural of a noun or adjective known to lex
+func pluralOf(word string, lex Lexicon) (string, bool) {
+	var candidates []string
+	switch {
+	case strings.HasSuffix(word, "al"):
+		candidates = append(candidates, strings.TrimSuffix(word, "al")+"aux")
+	case strings.HasSuffix(word, "eau"), strings.HasSuffix(word, "au"), strings.HasSuffix(word, "eu"):
+		candidates = append(candidates, word+"x")
+	}
+	candidates = append(candidates, word+"s")
+
+	for _, c := range candidates {
+		if lex.IsCorrect(c) {
+			return c, true
+		}
+	}
+	return "", false
+}
+
+// Noun endings that almost always give the gender, with the common
+// exceptions. Past participles used as nouns, such as "un invité", are
+// told apart from -ité nouns by participle.
+var (
+	feminineEndings    = []string{"tion", "sion", "ette", "ance", "ence", "esse", "ité"}
+	feminineExceptions = []string{
+		"bastion", "squelette", "silence", "comité", "traité", "invité", "retraité",
+	}
+	masculineEndings    = []string{"ment", "isme", "age"}
+	masculineExceptions = []string{
+		"jument", "page", "plage", "image", "cage", "rage", "nage", "sage",
+	}
+)
+
+// Determiners by gender, each mapped to the other gender
+var (
+	toFeminine = map[string]string{
+		"un": "une", "le": "la", "ce": "cette", "cet": "cette",
+		"mon": "ma", "ton": "ta", "son": "sa",
+	}
+	toMasculine = map[string]string{
+		"une": "un", "la": "le", "cette": "ce",
+		"ma": "mon", "ta": "ton", "sa": "son",
+	}
+)
+
+// genderAgreement flags a determiner of the wrong gender before a noun
+// whose ending gives its gender: "un question" → "une question"
+func genderAgreement(sentence []token, i int, lex Lexicon) (Issue, bool) {
+	noun := sentence[i]
+	det, ok := previous(sentence, i)
+	if !ok || det.Prefix != "" || !noun.isWord() || noun.Prefix != "" {
+		return Issue{}, false
+	}
+	if _, ok := previous(sentence, i-1); ok && subjectAt(sentence, i-2, lex) {
+		return Issue{}, false
+	}
+
+	vowel := startsWithVowel(noun.lower)
+	var fixed string
+	switch {
+	case hasEnding(noun.lower, feminineEndings, feminineExceptions) && !participle(noun.lower, lex):
+		fixed = toFeminine[det.lower]
+		// "mon amitié" is right and "la" would be elided before a vowel
+		if vowel && det.lower != "un" && det.lower != "cet" {
+			fixed = ""
+		}
+	case hasEnding(noun.lower, masculineEndings, masculineExceptions):
+		fixed = toMasculine[det.lower]
+		if vowel && det.lower == "la" {
+			fixed = ""
+		}
+		if vowel && fixed == "ce" {
+			fixed = "cet"
+		}
+	}
+	if fixed == "" {
+		return Issue{}, false
+	}
+	return issue("gender-agreement", "“"+det.Word+"” does not match the gender of “"+noun.Word+"”",
+		sentence[i-1:i+1], det.rewrite(fixed), noun.text()), true
+}
+
+// participle returns true for the past participle of an -er verb known to
+// lex, such as "visité", which may be a masculine noun
+func participle(word string, lex Lexicon) bool {
+	stem, ok := strings.CutSuffix(word, "é")
+	return ok && lex != nil && lex.IsCorrect(stem+"er")
+}
+
+func hasEnding(word string, endings, exceptions []string) bool {
+	if in(word, exceptions...) {
+		return false
+	}
+	for _, ending := range endings {
+		if strings.HasSuffix(word, ending) && len(word) > len(ending)+1 {
+			return true
+		}
+	}
+	return false
+}
+
+func startsWithVowel(word string) bool {
+	for _, r := range word {
+		return strings.ContainsRune("aâàeéèêëiîïoôuûùyh", r)
+	}
+	return false
+}
+
+// avoir lists the forms of the auxiliary "avoir" followed by a past
+// participle in compound tenses
+var avoir = []string{
+	"ai", "as", "a", "avons", "avez", "ont",
+	"avais", "avait", "avions", "aviez", "avaient",
+	"aurai", "auras", "aura", "aurons", "aurez", "auront",
+	"aurais", "aurait", "aurions", "auriez", "auraient",
+}
+
+// adverbs may sit between the auxiliary and the participle
+var adverbs = []string{
+	"pas", "jamais", "plus", "bien", "déjà", "deja", "tout", "rien", "trop",
+	"beaucoup", "vraiment", "enfin", "encore", "toujours", "souvent", "mal",
+}
+
+// notInfinitives end in -er without being verbs
+var notInfinitives = []string{
+	"hier", "cher", "fier", "mer", "fer", "hiver", "ver", "super", "amer",
+	"enfer", "premier", "dernier", "entier", "léger", "leger", "cancer",
+	"laser", "poster",
+}
+
+// pastParticiple flags an infinitive after "avoir": "il a manger" →
+// "il a mangé"
+func pastParticiple(sentence []token, i int, _ Lexicon) (Issue, bool) {
+	verb := sentence[i]
+	if !verb.isWord() || verb.Prefix != "" || len(verb.lower) < 4 ||
+		!strings.HasSuffix(verb.lower, "er") || in(verb.lower, notInfinitives...) {
+		return Issue{}, false
+	}
+
+	aux, ok := previous(sentence, i)
+	at := i - 1
+	if ok && in(aux.lower, adverbs...) {
+		aux, ok = previous(sentence, at)
+		at--
+	}
+	if !ok || !in(aux.lower, avoir...) {
+		return Issue{}, false
+	}
+	// "a" and "as" are only the verb after a subject: "il commence a
+	// manger" lacks an accent on "à" instead
+	if aux.lower == "a" || aux.lower == "as" {
+		subject, ok := previous(sentence, at)
+		if aux.Prefix == "" && (!ok || !in(subject.lower, "il", "elle", "on", "qui", "tu", "ça", "cela", "y")) {
+			return Issue{}, false
+		}
+	}
+
+	participle := strings.TrimSuffix(verb.lower, "er") + "é"
+	return issue("past-participle", "Past participle after the auxiliary “avoir”",
+		sentence[i:i+1], verb.rewrite(participle)), true
+}
+
+// Words around "a" that call for the preposition "à"
+var (
+	beforePreposition = []string{
+		"est", "suis", "es", "sommes", "êtes", "etes", "sont", "était", "etait",
+		"étais", "etais", "été", "ete", "vais", "vas", "va", "allons", "allez",
+		"vont", "allait", "aller", "allé", "alle", "grâce", "grace", "face",
+		"quant", "rapport",
+	}
+	afterPreposition = []string{
+		"cause", "partir", "travers", "côté", "cote", "nouveau", "condition",
+		"propos", "présent", "present",
+	}
+)
+
+// prepositionA flags the verb "a" used for the preposition "à" and the
+// other way around: "il est a Paris" → "à", "a cause de" → "à cause de",
+// "il à mangé" → "a"
+func prepositionA(sentence []token, i int, lex Lexicon) (Issue, bool) {
+	const (
+		toPreposition = "“à” is a preposition, “a” the verb avoir"
+		toVerb        = "“a” is the verb avoir, “à” a preposition"
+	)
+	word := sentence[i]
+	prev, hasPrev := previous(sentence, i)
+
+	switch {
+	case word.lower == "à" && word.Prefix == "" && hasPrev && in(prev.lower, "il", "elle", "on", "ça", "cela", "qui", "y"):
+		return issue("preposition-a", toVerb, sentence[i:i+1], word.rewrite("a")), true
+	case word.lower != "a":
+	case strings.EqualFold(strings.TrimRight(word.Prefix, "'’"), "jusqu"),
+		hasPrev && word.Prefix == "" && in(prev.lower, beforePreposition...):
+		return issue("preposition-a", toPreposition, sentence[i:i+1], word.rewrite("à")), true
+	}
+
+	// The word after "a" shows it begins a prepositional phrase
+	if i == 0 || !word.joined || !in(word.lower, afterPreposition...) {
+		return Issue{}, false
+	}
+	a := sentence[i-1]
+	if a.lower != "a" || a.Prefix != "" {
+		return Issue{}, false
+	}
+	if _, ok := previous(sentence, i-1); ok && subjectAt(sentence, i-2, lex) {
+		return Issue{}, false
+	}
+	return issue("preposition-a", toPreposition, sentence[i-1:i+1], a.rewrite("à"), word.text()), true
+}
+
+// relativeOu flags "ou" used for the relative or interrogative "où" and
+// "où" used for the conjunction: "d'ou", "là ou", "Ou est", "où bien"
+func relativeOu(sentence []token, i int, _ Lexicon) (Issue, bool) {
+	const toRelative = "“où” refers to a place or time, “ou” offers a choice"
+	word := sentence[i]
+	prev, hasPrev := previous(sentence, i)
+
+	switch {
+	case word.lower == "ou" && strings.EqualFold(strings.TrimRight(word.Prefix, "'’"), "d"),
+		word.lower == "ou" && hasPrev && in(prev.lower, "là", "moment", "partout"):
+		return issue("relative-ou", toRelative, sentence[i:i+1], word.rewrite("où")), true
+	case !hasPrev || prev.Prefix != "":
+		return Issue{}, false
+	case prev.lower == "ou" && i == 1 &&
+		in(word.lower, "est", "sont", "es", "vas", "va", "vais", "allez", "allons", "était", "etait", "se"):
+		// A question starting with "Ou est"
+		return issue("relative-ou", toRelative, sentence[i-1:i+1], prev.rewrite("où"), word.text()), true
+	case prev.lower == "où" && word.lower == "bien":
+		return issue("relative-ou", "“ou bien” offers a choice",
+			sentence[i-1:i+1], prev.rewrite("ou"), word.text()), true
+	}
+	return Issue{}, false
+}
+
+// demonstrativeCes flags the possessive "ses" before a word marked with
+// -ci or -là, and "c'est" after a subject pronoun: "ses jours-ci" →
+// "ces jours-ci", "il c'est trompé" → "il s'est trompé"
+func demonstrativeCes(sentence []token, i int, _ Lexicon) (Issue, bool) {
+	word := sentence[i]
+	prev, hasPrev := previous(sentence, i)
+	switch {
+	case hasPrev && prev.lower == "ses" && prev.Prefix == "" &&
+		(strings.HasSuffix(word.lower, "-ci") || strings.HasSuffix(word.lower, "-là")):
+		return issue("demonstrative-ces", "“ces” points things out, “ses” marks possession",
+			sentence[i-1:i+1], prev.rewrite("ces"), word.text()), true
+	case word.lower == "est" && strings.EqualFold(strings.TrimRight(word.Prefix, "'’"), "c"):
+		if !hasPrev || !in(prev.lower, "il", "elle", "on") {
+			return Issue{}, false
+		}
+		prefix := "s" + word.Prefix[1:]
+		if word.Prefix[0] == 'C' {
+			prefix = "S" + word.Prefix[1:]
+		}
+		return issue("demonstrative-ces", "“s'est” is the reflexive verb, “c'est” introduces",
+			sentence[i:i+1], prefix+word.Word), true
+	}
+	return Issue{}, false
+}
@@ -0,0 +1,218 @@
+package grammar
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/axide-dev/axidev-corrige/internal/checker"
+	"github.com/axide-dev/axidev-corrige/internal/writing"
+)
+
+// ruleTest is a sentence and its rewrite by one rule, empty when the rule
+// must not flag it
+type ruleTest struct {
+	text string
+	want string
+}
+
+// newLexicon returns the embedded French checker
+func newLexicon(t *testing.T) Lexicon {
+	t.Helper()
+	// Use the embedded dictionary, not one installed by the user
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_DATA_HOME", filepath.Join(home, "data"))
+	c, err := checker.NewChecker("fr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+// words splits text into completed words separated by spaces
+func words(text string) []writing.Word {
+	fields := strings.Fields(text)
+	result := make([]writing.Word, len(fields))
+	for i, field := range fields {
+		result[i] = writing.Word{Text: field, Separator: " "}
+	}
+	return result
+}
+
+// rewrite returns text with the issues found by rule applied, or "" if it
+// found none
+func rewrite(text, rule string, lex Lexicon) string {
+	ws := words(text)
+	found := false
+	for _, issue := range Check(ws, lex) {
+		if issue.Rule != rule {
+			continue
+		}
+		found = true
+		for k := issue.Start; k < issue.End; k++ {
+			ws[k].Text = issue.Replacement[k-issue.Start]
+		}
+	}
+	if !found {
+		return ""
+	}
+	texts := make([]string, len(ws))
+	for i, w := range ws {
+		texts[i] = w.Text
+	}
+	return strings.Join(texts, " ")
+}
+
+func runRule(t *testing.T, rule string, tests []ruleTest) {
+	t.Helper()
+	lex := newLexicon(t)
+	for _, tt := range tests {
+		if got := rewrite(tt.text, rule, lex); got != tt.want {
+			t.Errorf("%s: %q → %q, want %q", rule, tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestPluralAgreement(t *testing.T) {
+	runRule(t, "plural-agreement", []ruleTest{
+		{"les maison", "les maisons"},
+		{"Les maison", "Les maisons"},
+		{"des cheval", "des chevaux"},
+		{"mes bateau", "mes bateaux"},
+		{"plusieurs jour", "plusieurs jours"},
+		{"Dans les maison", "Dans les maisons"},
+		{"il voit ces enfant", "il voit ces enfants"},
+
+		{"les maisons", ""},
+		{"les prix", ""},
+		{"les deux", ""},
+		{"je les mange", ""},
+		{"Paul les aime", ""},
+		{"Paul les garde", ""},
+		{"Kevin les porte", ""},
+		{"hier Marie les garde", ""},
+		{"pour les voir", ""},
+		{"les xqzt", ""},
+		{"l'les maison", ""},
+	})
+}
+
+func TestGenderAgreement(t *testing.T) {
+	runRule(t, "gender-agreement", []ruleTest{
+		{"un question", "une question"},
+		{"Un question", "Une question"},
+		{"le nation", "la nation"},
+		{"ce décision", "cette décision"},
+		{"un activité", "une activité"},
+		{"le vérité", "la vérité"},
+		{"la moment", "le moment"},
+		{"une garage", "un garage"},
+		{"cette village", "ce village"},
+		{"cette appartement", "cet appartement"},
+
+		// Masculine nouns with feminine endings and the other way around
+		{"le comité", ""},
+		{"le traité", ""},
+		{"un invité", ""},
+		{"un retraité", ""},
+		{"le député", ""},
+		{"un été", ""},
+		{"le silence", ""},
+		{"un squelette", ""},
+		{"la page", ""},
+		{"une image", ""},
+		{"la plage", ""},
+		{"la jument", ""},
+
+		// Common masculine -ment and -age nouns
+		{"le moment", ""},
+		{"un appartement", ""},
+		{"le gouvernement", ""},
+		{"un médicament", ""},
+		{"le fromage", ""},
+		{"un voyage", ""},
+		{"le village", ""},
+		{"le courage", ""},
+		{"le paysage", ""},
+		{"cet âge", ""},
+
+		{"mon activité", ""},
+		{"la question", ""},
+		{"il la traitement", ""},
+		{"Paul la traitement", ""},
+	})
+}
+
+func TestPastParticiple(t *testing.T) {
+	runRule(t, "past-participle", []ruleTest{
+		{"il a manger", "il a mangé"},
+		{"j'ai manger", "j'ai mangé"},
+		{"ils ont chanter", "ils ont chanté"},
+		{"il a bien manger", "il a bien mangé"},
+		{"nous avions parler", "nous avions parlé"},
+
+		{"il a mangé", ""},
+		{"il va manger", ""},
+		{"il commence a manger", ""},
+		{"il a hier", ""},
+		{"ils ont premier", ""},
+	})
+}
+
+func TestPrepositionA(t *testing.T) {
+	runRule(t, "preposition-a", []ruleTest{
+		{"il est a Paris", "il est à Paris"},
+		{"a cause de", "à cause de"},
+		{"jusqu'a demain", "jusqu'à demain"},
+		{"il à mangé", "il a mangé"},
+		{"elle va a Lyon", "elle va à Lyon"},
+
+		{"il a mangé", ""},
+		{"il a faim", ""},
+		{"elle va à Lyon", ""},
+		{"il a cause", ""},
+		{"Paul a cause", ""},
+	})
+}
+
+func TestRelativeOu(t *testing.T) {
+	runRule(t, "relative-ou", []ruleTest{
+		{"d'ou vient-il", "d'où vient-il"},
+		{"là ou il habite", "là où il habite"},
+		{"Ou est le chat", "Où est le chat"},
+		{"où bien", "ou bien"},
+
+		{"thé ou café", ""},
+		{"où est le chat", ""},
+		{"ou bien", ""},
+		{"il est ou pas", ""},
+	})
+}
+
+func TestDemonstrativeCes(t *testing.T) {
+	runRule(t, "demonstrative-ces", []ruleTest{
+		{"ses jours-ci", "ces jours-ci"},
+		{"il c'est trompé", "il s'est trompé"},
+		{"Elle C'est levée", "Elle S'est levée"},
+
+		{"ses amis", ""},
+		{"ces jours-ci", ""},
+		{"c'est vrai", ""},
+		{"il s'est trompé", ""},
+	})
+}
+
+func TestRulesStopAtSentenceEnd(t *testing.T) {
+	lex := newLexicon(t)
+	ws := []writing.Word{
+		{Text: "les", Separator: ". "},
+		{Text: "maison", Separator: " "},
+		{Text: "il", Separator: " "},
+		{Text: "a", Separator: "\n"},
+		{Text: "manger", Separator: " "},
+	}
+	if issues := Check(ws, lex); len(issues) != 0 {
+		t.Errorf("issues across sentences: %+v", issues)
+	}
+}
@@ -0,0 +1,130 @@
+package hunspell
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Flag identifies an affix class or a word property in a dictionary
+type Flag string
+
+// affix is a single PFX or SFX rule
+type affix struct {
+	flag   Flag
+	prefix bool
+	// cross allows combining the rule with an affix of the other kind
+	cross bool
+	strip string
+	add   string
+	cond  condition
+	// cont lists the flags of affixes that may be applied on top of this one
+	cont []Flag
+}
+
+// apply returns the word with the affix applied, false if the rule does
+// not apply to it. Like Hunspell without FULLSTRIP, a rule cannot strip
+// the whole word.
+func (a *affix) apply(word string) (string, bool) {
+	if len(a.strip) >= len(word) {
+		return "", false
+	}
+	if a.prefix {
+		if !strings.HasPrefix(word, a.strip) || !a.cond.matchStart(word) {
+			return "", false
+		}
+		return a.add + word[len(a.strip):], true
+	}
+	if !strings.HasSuffix(word, a.strip) || !a.cond.matchEnd(word) {
+		return "", false
+	}
+	return word[:len(word)-len(a.strip)] + a.add, true
+}
+
+// charClass is one position of a condition: a letter, a bracketed set or
+// any letter
+type charClass struct {
+	any    bool
+	negate bool
+	set    string
+}
+
+func (c charClass) match(r rune) bool {
+	if c.any {
+		return true
+	}
+	return strings.ContainsRune(c.set, r) != c.negate
+}
+
+// condition is the pattern the word must match for an affix to apply,
+// written like "[^aeiou]y" or "."
+type condition []charClass
+
+// parseCondition parses a condition, "." meaning any word
+func parseCondition(s string) (condition, error) {
+	if s == "." {
+		return nil, nil
+	}
+
+	var cond condition
+	for len(s) > 0 {
+		r, size := utf8.DecodeRuneInString(s)
+		switch r {
+		case '.':
+			cond = append(cond, charClass{any: true})
+			s = s[size:]
+		case '[':
+			end := strings.IndexByte(s, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated set in condition %q", s)
+			}
+			class := charClass{set: s[1:end]}
+			if strings.HasPrefix(class.set, "^") {
+				class.negate = true
+				class.set = class.set[1:]
+			}
+			cond = append(cond, class)
+			s = s[end+1:]
+		default:
+			cond = append(cond, charClass{set: string(r)})
+			s = s[size:]
+		}
+	}
+	return cond, nil
+}
+
+// matchStart returns true if the beginning of word matches the condition
+func (c condition) matchStart(word string) bool {
+	for _, class := range c {
+		r, size := utf8.DecodeRuneInString(word)
+		if size == 0 || !class.match(r) {
+			return false
+		}
+		word = word[size:]
+	}
+	return true
+}
+
+// matchEnd returns true if the end of word matches the condition
+func (c condition) matchEnd(word string) bool {
+	for i := len(c) - 1; i >= 0; i-- {
+		r, size := utf8.DecodeLastRuneInString(word)
+		if size == 0 || !c[i].match(r) {
+			return false
+		}
+		word = word[:len(word)-size]
+	}
+	return true
+}
+
+func hasFlag(flags []Flag, flag Flag) bool {
+	if flag == "" {
+		return false
+	}
+	for _, f := range flags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
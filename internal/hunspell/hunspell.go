@@ -0,0 +1,436 @@
+// Package hunspell reads Hunspell dictionaries: an affix file (.aff)
+// describing prefix and suffix rules and a dictionary file (.dic) listing
+// root words with the flags of the rules they accept.
+package hunspell
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Dictionary holds the root words and affix rules of a Hunspell dictionary
+type Dictionary struct {
+	flagType  string
+	aliases   [][]Flag
+	prefixes  map[Flag][]*affix
+	suffixes  map[Flag][]*affix
+	needAffix Flag
+	forbidden Flag
+	entries   []entry
+}
+
+// entry is a root word of the .dic file
+type entry struct {
+	word  string
+	flags []Flag
+}
+
+// Load reads the dictionary from an affix file and a dictionary file
+func Load(affPath, dicPath string) (*Dictionary, error) {
+	aff, err := os.ReadFile(affPath)
+	if err != nil {
+		return nil, err
+	}
+	dic, err := os.ReadFile(dicPath)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := Parse(aff, dic)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Hunspell dictionary %s: %w", dicPath, err)
+	}
+	return d, nil
+}
+
+// Parse reads the dictionary from the contents of its affix and
+// dictionary files
+func Parse(aff, dic []byte) (*Dictionary, error) {
+	decode, err := decoderFor(aff)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Dictionary{
+		prefixes: make(map[Flag][]*affix),
+		suffixes: make(map[Flag][]*affix),
+	}
+	if err := d.parseAffixes(decode(aff)); err != nil {
+		return nil, err
+	}
+	if err := d.parseWords(decode(dic)); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// RootCount returns the number of root words in the dictionary
+func (d *Dictionary) RootCount() int {
+	return len(d.entries)
+}
+
+// Words returns every form the dictionary accepts: the root words and the
+// words derived from them by their prefixes and suffixes, including
+// combined prefix and suffix forms and suffixes on top of suffixes
+func (d *Dictionary) Words() []string {
+	seen := make(map[string]bool, len(d.entries)*4)
+	forbidden := make(map[string]bool)
+	for _, e := range d.entries {
+		if hasFlag(e.flags, d.forbidden) {
+			forbidden[e.word] = true
+		}
+	}
+
+	var words []string
+	add := func(word string, cont []Flag) {
+		if word == "" || seen[word] || forbidden[word] || hasFlag(cont, d.needAffix) {
+			return
+		}
+		seen[word] = true
+		words = append(words, word)
+	}
+
+	for _, e := range d.entries {
+		if forbidden[e.word] {
+			continue
+		}
+		add(e.word, e.flags)
+
+		// Suffixed forms allowing a prefix are kept for the cross products
+		var crossable []string
+		for _, flag := range e.flags {
+			for _, sfx := range d.suffixes[flag] {
+				form, ok := sfx.apply(e.word)
+				if !ok {
+					continue
+				}
+				add(form, sfx.cont)
+				if sfx.cross {
+					crossable = append(crossable, form)
+				}
+				for _, contFlag := range sfx.cont {
+					for _, next := range d.suffixes[contFlag] {
+						if twice, ok := next.apply(form); ok {
+							add(twice, next.cont)
+						}
+					}
+				}
+			}
+		}
+
+		for _, flag := range e.flags {
+			for _, pfx := range d.prefixes[flag] {
+				if form, ok := pfx.apply(e.word); ok {
+					add(form, pfx.cont)
+				}
+				if !pfx.cross {
+					continue
+				}
+				for _, base := range crossable {
+					if form, ok := pfx.apply(base); ok {
+						add(form, pfx.cont)
+					}
+				}
+			}
+		}
+	}
+	return words
+}
+
+// parseAffixes reads the options and rules of the affix file
+func (d *Dictionary) parseAffixes(data string) error {
+	headers := make(map[string]*affixHeader)
+
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		var err error
+		switch fields[0] {
+		case "FLAG":
+			if len(fields) < 2 {
+				err = fmt.Errorf("missing flag type")
+				break
+			}
+			switch fields[1] {
+			case "long", "num", "UTF-8":
+				d.flagType = fields[1]
+			default:
+				err = fmt.Errorf("unsupported flag type %q", fields[1])
+			}
+		case "AF":
+			err = d.parseAlias(fields)
+		case "NEEDAFFIX":
+			d.needAffix, err = d.singleFlag(fields)
+		case "FORBIDDENWORD":
+			d.forbidden, err = d.singleFlag(fields)
+		case "PFX", "SFX":
+			err = d.parseAffix(fields, headers)
+		}
+		if err != nil {
+			return fmt.Errorf("affix file line %d: %w", line, err)
+		}
+	}
+	return scanner.Err()
+}
+
+// parseAlias reads an AF line. The first one gives the number of aliases,
+// the next ones are the flag sets numbered from 1 in the dictionary file.
+func (d *Dictionary) parseAlias(fields []string) error {
+	if len(fields) < 2 {
+		return fmt.Errorf("missing flags")
+	}
+	if d.aliases == nil {
+		if _, err := strconv.Atoi(fields[1]); err == nil {
+			d.aliases = make([][]Flag, 0)
+			return nil
+		}
+	}
+	flags, err := d.parseFlags(fields[1])
+	if err != nil {
+		return err
+	}
+	d.aliases = append(d.aliases, flags)
+	return nil
+}
+
+// affixHeader holds the settings of a PFX or SFX block while its rules
+// are read
+type affixHeader struct {
+	cross     bool
+	remaining int
+}
+
+// parseAffix reads a PFX or SFX header ("SFX A Y 2") or rule
+// ("SFX A 0 s [^s]"), headers being keyed by kind and flag
+func (d *Dictionary) parseAffix(fields []string, headers map[string]*affixHeader) error {
+	if len(fields) < 4 {
+		return fmt.Errorf("incomplete %s line", fields[0])
+	}
+	flag := Flag(fields[1])
+	key := fields[0] + " " + fields[1]
+
+	header := headers[key]
+	if header == nil || header.remaining == 0 {
+		count, err := strconv.Atoi(fields[3])
+		if err != nil || count < 0 {
+			return fmt.Errorf("invalid %s rule count %q", fields[0], fields[3])
+		}
+		headers[key] = &affixHeader{cross: fields[2] == "Y", remaining: count}
+		return nil
+	}
+	header.remaining--
+
+	a := &affix{
+		flag:   flag,
+		prefix: fields[0] == "PFX",
+		cross:  header.cross,
+		strip:  zeroEmpty(fields[2]),
+	}
+	add := fields[3]
+	if i := strings.IndexByte(add, '/'); i >= 0 {
+		cont, err := d.flagsOrAlias(add[i+1:])
+		if err != nil {
+			return err
+		}
+		a.cont = cont
+		add = add[:i]
+	}
+	a.add = zeroEmpty(add)
+
+	condition := "."
+	if len(fields) > 4 {
+		condition = fields[4]
+	}
+	cond, err := parseCondition(condition)
+	if err != nil {
+		return err
+	}
+	a.cond = cond
+
+	if a.prefix {
+		d.prefixes[flag] = append(d.prefixes[flag], a)
+	} else {
+		d.suffixes[flag] = append(d.suffixes[flag], a)
+	}
+	return nil
+}
+
+// parseWords reads the dictionary file: a word count, then one
+// "word/flags" per line optionally followed by morphological fields
+func (d *Dictionary) parseWords(data string) error {
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	first := true
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		if first {
+			first = false
+			if count, err := strconv.Atoi(text); err == nil {
+				d.entries = make([]entry, 0, count)
+				continue
+			}
+		}
+
+		if i := strings.IndexAny(text, " \t"); i >= 0 {
+			text = text[:i]
+		}
+		word, flags := splitEntry(text)
+		e := entry{word: word}
+		if flags != "" {
+			var err error
+			if e.flags, err = d.flagsOrAlias(flags); err != nil {
+				return fmt.Errorf("dictionary file line %d: %w", line, err)
+			}
+		}
+		d.entries = append(d.entries, e)
+	}
+	return scanner.Err()
+}
+
+// splitEntry splits "word/flags" at the first slash not escaped with a
+// backslash
+func splitEntry(text string) (word, flags string) {
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '/':
+			return strings.ReplaceAll(text[:i], `\/`, "/"), text[i+1:]
+		}
+	}
+	return strings.ReplaceAll(text, `\/`, "/"), ""
+}
+
+// flagsOrAlias parses flags, resolving AF alias numbers when the affix
+// file defines aliases
+func (d *Dictionary) flagsOrAlias(s string) ([]Flag, error) {
+	if d.aliases == nil {
+		return d.parseFlags(s)
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 || n > len(d.aliases) {
+		return nil, fmt.Errorf("invalid flag alias %q", s)
+	}
+	return d.aliases[n-1], nil
+}
+
+// parseFlags splits a flag string according to the FLAG type: one
+// character per flag by default, two with "long", comma separated numbers
+// with "num"
+func (d *Dictionary) parseFlags(s string) ([]Flag, error) {
+	switch d.flagType {
+	case "long":
+		runes := []rune(s)
+		if len(runes)%2 != 0 {
+			return nil, fmt.Errorf("odd number of characters in long flags %q", s)
+		}
+		flags := make([]Flag, 0, len(runes)/2)
+		for i := 0; i < len(runes); i += 2 {
+			flags = append(flags, Flag(runes[i:i+2]))
+		}
+		return flags, nil
+	case "num":
+		parts := strings.Split(s, ",")
+		flags := make([]Flag, 0, len(parts))
+		for _, part := range parts {
+			if _, err := strconv.Atoi(part); err != nil {
+				return nil, fmt.Errorf("invalid numeric flag %q", part)
+			}
+			flags = append(flags, Flag(part))
+		}
+		return flags, nil
+	default:
+		flags := make([]Flag, 0, utf8.RuneCountInString(s))
+		for _, r := range s {
+			flags = append(flags, Flag(string(r)))
+		}
+		return flags, nil
+	}
+}
+
+// singleFlag parses the flag argument of an option such as NEEDAFFIX
+func (d *Dictionary) singleFlag(fields []string) (Flag, error) {
+	if len(fields) < 2 {
+		return "", fmt.Errorf("missing flag for %s", fields[0])
+	}
+	flags, err := d.parseFlags(fields[1])
+	if err != nil {
+		return "", err
+	}
+	if len(flags) != 1 {
+		return "", fmt.Errorf("expected one flag for %s, got %q", fields[0], fields[1])
+	}
+	return flags[0], nil
+}
+
+func zeroEmpty(s string) string {
+	if s == "0" {
+		return ""
+	}
+	return s
+}
+
+// decoderFor returns a function converting text in the encoding named by
+// the SET option of the affix file to UTF-8
+func decoderFor(aff []byte) (func([]byte) string, error) {
+	charset := "ISO8859-1"
+	scanner := bufio.NewScanner(bytes.NewReader(aff))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 2 && fields[0] == "SET" {
+			charset = strings.ToUpper(fields[1])
+			break
+		}
+	}
+
+	switch charset {
+	case "UTF-8", "UTF8":
+		return func(data []byte) string {
+			return string(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")))
+		}, nil
+	case "ISO8859-1", "ISO-8859-1":
+		return func(data []byte) string { return decodeLatin(data, nil) }, nil
+	case "ISO8859-15", "ISO-8859-15":
+		return func(data []byte) string { return decodeLatin(data, latin9) }, nil
+	default:
+		return nil, fmt.Errorf("unsupported encoding %q", charset)
+	}
+}
+
+// latin9 lists the ISO 8859-15 characters that differ from ISO 8859-1
+var latin9 = map[byte]rune{
+	0xa4: '€', 0xa6: 'Š', 0xa8: 'š', 0xb4: 'Ž',
+	0xb8: 'ž', 0xbc: 'Œ', 0xbd: 'œ', 0xbe: 'Ÿ',
+}
+
+// decodeLatin converts single-byte text to UTF-8, each byte being the
+// code point of the same value unless overridden
+func decodeLatin(data []byte, overrides map[byte]rune) string {
+	var b strings.Builder
+	b.Grow(len(data))
+	for _, c := range data {
+		if r, ok := overrides[c]; ok {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune(rune(c))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,240 @@
+package hunspell
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestConditionMatch(t *testing.T) {
+	tests := []struct {
+		cond       string
+		word       string
+		start, end bool
+	}{
+		{".", "chat", true, true},
+		{".", "", true, true},
+		{"e", "eau", true, false},
+		{"e", "porte", false, true},
+		{"[^aeiou]y", "baby", false, true},
+		{"[^aeiou]y", "boy", false, false},
+		{"[aeiou]y", "boy", false, true},
+		{"[ée]r", "érable", true, false},
+		{"ér", "péér", false, true},
+		{".e", "ce", true, true},
+		{"..e", "e", false, false},
+		{"[^s]", "chats", true, false},
+		{"[^s]", "chat", true, true},
+		{"al", "cheval", false, true},
+		{"[cç]a", "ça", true, true},
+	}
+	for _, tt := range tests {
+		cond, err := parseCondition(tt.cond)
+		if err != nil {
+			t.Fatalf("parseCondition(%q): %v", tt.cond, err)
+		}
+		if got := cond.matchStart(tt.word); got != tt.start {
+			t.Errorf("%q matchStart(%q) = %v, want %v", tt.cond, tt.word, got, tt.start)
+		}
+		if got := cond.matchEnd(tt.word); got != tt.end {
+			t.Errorf("%q matchEnd(%q) = %v, want %v", tt.cond, tt.word, got, tt.end)
+		}
+	}
+}
+
+func TestParseConditionErrors(t *testing.T) {
+	for _, cond := range []string{"[abc", "a[^e", "["} {
+		if _, err := parseCondition(cond); err == nil {
+			t.Errorf("parseCondition(%q) accepted an unterminated set", cond)
+		}
+	}
+}
+
+func TestAffixApply(t *testing.T) {
+	tests := []struct {
+		name string
+		rule string
+		word string
+		want string // "" when the rule does not apply
+	}{
+		{"add only", "SFX A 0 s .", "chat", "chats"},
+		{"strip only", "SFX A e 0 e", "porte", "port"},
+		{"strip and add", "SFX A al aux al", "cheval", "chevaux"},
+		{"strip mismatch", "SFX A al aux al", "chat", ""},
+		{"condition mismatch", "SFX A 0 s [^s]", "pas", ""},
+		{"condition longer than strip", "SFX A er é ger", "manger", "mangé"},
+		{"condition longer than strip mismatch", "SFX A er é ger", "parler", ""},
+		{"prefix add", "PFX B 0 re .", "faire", "refaire"},
+		{"prefix strip", "PFX B é dé é", "équiper", "déquiper"},
+		{"prefix condition", "PFX B 0 in [^aeiou]", "utile", ""},
+		{"multibyte strip", "SFX A é ée é", "aimé", "aimée"},
+		{"whole word", "SFX A eau 0 eau", "eau", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields := strings.Fields(tt.rule)
+			aff := "SET UTF-8\n" + fields[0] + " " + fields[1] + " N 1\n" + tt.rule + "\n"
+			d, err := Parse([]byte(aff), []byte("1\n"+tt.word+"/"+fields[1]+"\n"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			affixes := d.suffixes[Flag(fields[1])]
+			if fields[0] == "PFX" {
+				affixes = d.prefixes[Flag(fields[1])]
+			}
+			if len(affixes) != 1 {
+				t.Fatalf("parsed %d rules, want 1", len(affixes))
+			}
+			got, ok := affixes[0].apply(tt.word)
+			if ok != (tt.want != "") || got != tt.want {
+				t.Errorf("apply(%q) = %q, %v, want %q", tt.word, got, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestWords(t *testing.T) {
+	tests := []struct {
+		name string
+		aff  string
+		dic  string
+		want []string
+	}{
+		{
+			name: "suffixes",
+			aff:  "SFX S Y 2\nSFX S 0 s [^sxl]\nSFX S al aux al\n",
+			dic:  "3\nchat/S\ncheval/S\nbras/S\n",
+			want: []string{"bras", "chat", "chats", "cheval", "chevaux"},
+		},
+		{
+			name: "cross product",
+			aff:  "PFX R Y 1\nPFX R 0 re .\nSFX S Y 1\nSFX S 0 s .\n",
+			dic:  "1\nfaire/RS\n",
+			want: []string{"faire", "faires", "refaire", "refaires"},
+		},
+		{
+			name: "no cross product without Y on both",
+			aff:  "PFX R N 1\nPFX R 0 re .\nSFX S Y 1\nSFX S 0 s .\n",
+			dic:  "1\nfaire/RS\n",
+			want: []string{"faire", "faires", "refaire"},
+		},
+		{
+			name: "suffix without cross",
+			aff:  "PFX R Y 1\nPFX R 0 re .\nSFX S N 1\nSFX S 0 s .\n",
+			dic:  "1\nfaire/RS\n",
+			want: []string{"faire", "faires", "refaire"},
+		},
+		{
+			name: "two-level suffixes",
+			aff:  "SFX E Y 1\nSFX E 0 e/S .\nSFX S Y 1\nSFX S 0 s .\n",
+			dic:  "1\npetit/E\n",
+			want: []string{"petit", "petite", "petites"},
+		},
+		{
+			name: "needaffix",
+			aff:  "NEEDAFFIX !\nSFX S Y 1\nSFX S 0 s .\n",
+			dic:  "1\nciseau/S!\n",
+			want: []string{"ciseaus"},
+		},
+		{
+			name: "needaffix on continuation",
+			aff:  "NEEDAFFIX !\nSFX E Y 1\nSFX E 0 e/S! .\nSFX S Y 1\nSFX S 0 s .\n",
+			dic:  "1\npetit/E\n",
+			want: []string{"petit", "petites"},
+		},
+		{
+			name: "forbidden word",
+			aff:  "FORBIDDENWORD *\nSFX S Y 1\nSFX S 0 s .\n",
+			dic:  "2\nchou/S\nchous/*\n",
+			want: []string{"chou"},
+		},
+		{
+			name: "long flags",
+			aff:  "FLAG long\nSFX Aa Y 1\nSFX Aa 0 s .\n",
+			dic:  "1\nchat/AaBb\n",
+			want: []string{"chat", "chats"},
+		},
+		{
+			name: "numeric flags",
+			aff:  "FLAG num\nSFX 12 Y 1\nSFX 12 0 s .\n",
+			dic:  "1\nchat/3,12\n",
+			want: []string{"chat", "chats"},
+		},
+		{
+			name: "flag aliases",
+			aff:  "AF 2\nAF S\nAF E\nSFX E Y 1\nSFX E 0 e/1 .\nSFX S Y 1\nSFX S 0 s .\n",
+			dic:  "1\npetit/2\n",
+			want: []string{"petit", "petite", "petites"},
+		},
+		{
+			name: "escaped slash and morphology",
+			aff:  "SFX S Y 1\nSFX S 0 s .\n",
+			dic:  "2\n1\\/2\nchat/S po:nom\n",
+			want: []string{"1/2", "chat", "chats"},
+		},
+		{
+			name: "comments and blank lines",
+			aff:  "# comment\n\nSFX S Y 1\n# between rules\nSFX S 0 s .\n",
+			dic:  "1\n\nchat/S\n",
+			want: []string{"chat", "chats"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := Parse([]byte(tt.aff), []byte(tt.dic))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := d.Words()
+			slices.Sort(got)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Words() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseEncoding(t *testing.T) {
+	aff := []byte("SET ISO8859-15\nSFX S Y 1\nSFX S 0 s .\n")
+	dic := []byte("1\n\xbduf/S\n") // "œuf" in Latin-9
+	d, err := Parse(aff, dic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := d.Words()
+	slices.Sort(got)
+	if want := []string{"œuf", "œufs"}; !slices.Equal(got, want) {
+		t.Errorf("Words() = %q, want %q", got, want)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		aff  string
+		dic  string
+	}{
+		{"incomplete header", "SFX S Y\n", "0\n"},
+		{"invalid rule count", "SFX S Y many\n", "0\n"},
+		{"negative rule count", "SFX S Y -1\n", "0\n"},
+		{"rule without header", "SFX S 0 s .\n", "0\n"},
+		{"incomplete rule", "SFX S Y 1\nSFX S 0\n", "0\n"},
+		{"unterminated condition", "SFX S Y 1\nSFX S 0 s [^s\n", "0\n"},
+		{"missing flag type", "FLAG\n", "0\n"},
+		{"unknown flag type", "FLAG short\n", "0\n"},
+		{"odd long flags", "FLAG long\n", "1\nchat/ABC\n"},
+		{"invalid numeric flag", "FLAG num\n", "1\nchat/1,x\n"},
+		{"invalid alias", "AF 1\nAF S\n", "1\nchat/2\n"},
+		{"alias in rule out of range", "AF 1\nAF S\nSFX S Y 1\nSFX S 0 e/5 .\n", "0\n"},
+		{"missing needaffix flag", "NEEDAFFIX\n", "0\n"},
+		{"several forbiddenword flags", "FLAG long\nFORBIDDENWORD AaBb\n", "0\n"},
+		{"unsupported encoding", "SET KOI8-R\n", "0\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse([]byte(tt.aff), []byte(tt.dic)); err == nil {
+				t.Error("Parse() accepted a malformed dictionary")
+			}
+		})
+	}
+}
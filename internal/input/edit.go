@@ -0,0 +1,121 @@
+package input
+
+import (
+	"github.com/axide-dev/axidev-io-go/keyboard"
+)
+
+// EditAction is a cursor or deletion operation derived from a key press
+type EditAction int
+
+const (
+	// EditNone - the key does not edit the tracked text
+	EditNone EditAction = iota
+	// EditBackspace - delete the character before the cursor
+	EditBackspace
+	// EditDeleteWordBackward - delete up to the start of the word
+	EditDeleteWordBackward
+	// EditDelete - delete the character after the cursor
+	EditDelete
+	// EditLeft - move the cursor one character left
+	EditLeft
+	// EditRight - move the cursor one character right
+	EditRight
+	// EditWordLeft - move the cursor to the previous word boundary
+	EditWordLeft
+	// EditWordRight - move the cursor to the next word boundary
+	EditWordRight
+	// EditHome - move the cursor to the start of the line
+	EditHome
+	// EditEnd - move the cursor to the end of the line
+	EditEnd
+	// EditReset - the cursor moves somewhere the buffer cannot follow
+	// (vertical moves, selections, shortcuts)
+	EditReset
+)
+
+func (a EditAction) String() string {
+	switch a {
+	case EditNone:
+		return "none"
+	case EditBackspace:
+		return "backspace"
+	case EditDeleteWordBackward:
+		return "delete-word-backward"
+	case EditDelete:
+		return "delete"
+	case EditLeft:
+		return "left"
+	case EditRight:
+		return "right"
+	case EditWordLeft:
+		return "word-left"
+	case EditWordRight:
+		return "word-right"
+	case EditHome:
+		return "home"
+	case EditEnd:
+		return "end"
+	case EditReset:
+		return "reset"
+	default:
+		return "unknown"
+	}
+}
+
+var (
+	deleteKey   = keyboard.StringToKey("Delete")
+	leftKey     = keyboard.StringToKey("Left")
+	rightKey    = keyboard.StringToKey("Right")
+	homeKey     = keyboard.StringToKey("Home")
+	endKey      = keyboard.StringToKey("End")
+	upKey       = keyboard.StringToKey("Up")
+	downKey     = keyboard.StringToKey("Down")
+	pageUpKey   = keyboard.StringToKey("PageUp")
+	pageDownKey = keyboard.StringToKey("PageDown")
+)
+
+// EditActionFor maps a key press to the edit it performs on the text.
+// Word jumps and word deletion use Ctrl on Windows/Linux and Alt on macOS;
+// both are accepted. Ctrl+Alt is treated as AltGr and never as a shortcut.
+func EditActionFor(event keyboard.KeyEvent) EditAction {
+	mods := event.Modifiers & relevantModifiers
+	wordMod := (mods.HasCtrl() || mods.HasAlt()) && !(mods.HasCtrl() && mods.HasAlt())
+
+	switch event.Key {
+	case backspaceKey:
+		if wordMod {
+			return EditDeleteWordBackward
+		}
+		return EditBackspace
+	case deleteKey:
+		return EditDelete
+	case leftKey, rightKey:
+		if mods.HasShift() || mods.HasSuper() {
+			// Selections and line jumps
+			return EditReset
+		}
+		if event.Key == leftKey {
+			if wordMod {
+				return EditWordLeft
+			}
+			return EditLeft
+		}
+		if wordMod {
+			return EditWordRight
+		}
+		return EditRight
+	case homeKey:
+		return EditHome
+	case endKey:
+		return EditEnd
+	case upKey, downKey, pageUpKey, pageDownKey:
+		return EditReset
+	}
+
+	// Shortcuts such as paste, undo or select all change the text in ways
+	// the buffer cannot follow
+	if mods.HasSuper() || (mods.HasCtrl() && !mods.HasAlt()) {
+		return EditReset
+	}
+	return EditNone
+}
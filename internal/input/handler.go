@@ -2,22 +2,37 @@ package input
 
 import (
 	"fmt"
-	"runtime"
+	"log/slog"
+	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/axide-dev/axidev-corrige/internal/tokenize"
 
 	"github.com/axide-dev/axidev-io-go/keyboard"
 )
 
+var (
+	backspaceKey = keyboard.StringToKey("Backspace")
+	returnKey    = keyboard.StringToKey("Return")
+	tabKey       = keyboard.StringToKey("Tab")
+	escapeKey    = keyboard.StringToKey("Escape")
+)
+
 // Handler processes keyboard input events
 type Handler struct {
 	listener *keyboard.Listener
 	sender   *keyboard.Sender
 	callback func(event keyboard.KeyEvent)
+	log      *slog.Logger
 }
 
 // Config holds input handler configuration
 type Config struct {
 	OnEvent func(event keyboard.KeyEvent)
+	// Logger receives diagnostics, nothing is logged if nil
+	Logger *slog.Logger
 }
 
 // NewHandler creates a new input handler
@@ -33,10 +48,16 @@ func NewHandler(cfg Config) (*Handler, error) {
 		return nil, fmt.Errorf("failed to create sender: %w", err)
 	}
 
+	log := cfg.Logger
+	if log == nil {
+		log = slog.New(slog.DiscardHandler)
+	}
+
 	return &Handler{
 		listener: listener,
 		sender:   sender,
 		callback: cfg.OnEvent,
+		log:      log,
 	}, nil
 }
 
@@ -66,7 +87,7 @@ func (h *Handler) RequestPermissions() bool {
 
 	caps := h.sender.Capabilities()
 	if caps.NeedsAccessibilityPerm {
-		fmt.Println("Requesting accessibility permissions...")
+		h.log.Info("Requesting accessibility permissions")
 		return h.sender.RequestPermissions()
 	}
 	return true
@@ -85,36 +106,32 @@ func (h *Handler) CanSend() bool {
 	return h.sender != nil
 }
 
-// ReplaceWord selects and replaces the current word with a correction
-func (h *Handler) ReplaceWord(correction string) error {
+// ReplaceWord replaces the word just typed, followed by the separator that
+// ended it, with the correction and the same separator
+func (h *Handler) ReplaceWord(original, correction, separator string) error {
+	count := utf8.RuneCountInString(original) + utf8.RuneCountInString(separator)
+	if err := h.Retype(count, correction+separator); err != nil {
+		return fmt.Errorf("error replacing word: %w", err)
+	}
+	return nil
+}
+
+// Retype erases count characters before the cursor and types text in
+// their place
+func (h *Handler) Retype(count int, text string) error {
 	if h.sender == nil {
 		return fmt.Errorf("sender not available")
 	}
 
-	leftKey := keyboard.StringToKey("Left")
-	backspaceKey := keyboard.StringToKey("Backspace")
-
-	// Select the word using platform-specific modifier
-	if runtime.GOOS == "darwin" {
-		// Alt + Shift + Left Arrow for macOS
-		if err := h.sender.Combo(keyboard.ModAlt|keyboard.ModShift, leftKey); err != nil {
-			return fmt.Errorf("error selecting word: %w", err)
-		}
-	} else {
-		// Ctrl + Shift + Left Arrow for Windows and Linux
-		if err := h.sender.Combo(keyboard.ModCtrl|keyboard.ModShift, leftKey); err != nil {
-			return fmt.Errorf("error selecting word: %w", err)
+	h.log.Debug("Retyping", "erase", count, "type", utf8.RuneCountInString(text))
+	for i := 0; i < count; i++ {
+		if err := h.sender.Tap(backspaceKey); err != nil {
+			return fmt.Errorf("error deleting text: %w", err)
 		}
 	}
 
-	// Delete the selected word
-	if err := h.sender.Tap(backspaceKey); err != nil {
-		return fmt.Errorf("error deleting word: %w", err)
-	}
-
-	// Type the correction with trailing space
-	if err := h.sender.TypeText(correction + " "); err != nil {
-		return fmt.Errorf("error typing correction: %w", err)
+	if err := h.sender.TypeText(text); err != nil {
+		return fmt.Errorf("error typing text: %w", err)
 	}
 
 	h.sender.Flush()
@@ -137,13 +154,42 @@ func (h *Handler) Flush() {
 }
 
 // IsWordSeparator returns true if the rune is a word separator
+// (whitespace or punctuation, see tokenize.IsSeparator)
 func IsWordSeparator(r rune) bool {
-	return r == ' ' || r == '\n' || r == '\t' || r == '\r'
+	return tokenize.IsSeparator(r)
+}
+
+// IsBackspace returns true if the event is a plain Backspace press
+func IsBackspace(event keyboard.KeyEvent) bool {
+	return event.Key == backspaceKey && event.Modifiers&relevantModifiers == 0
+}
+
+// IsFieldExit returns true if the key usually submits or leaves the
+// focused field: Enter, Tab or Escape
+func IsFieldExit(event keyboard.KeyEvent) bool {
+	switch event.Key {
+	case returnKey, tabKey, escapeKey:
+		return true
+	}
+	return tokenize.IsLineBreak(event.Rune())
+}
+
+// IsModifierKey returns true if the event is for a modifier key alone
+// (Shift, Ctrl, Alt, Super and lock keys)
+func IsModifierKey(event keyboard.KeyEvent) bool {
+	name := strings.ToLower(event.KeyName())
+	for _, mod := range []string{"shift", "control", "ctrl", "alt", "super", "meta", "lock"} {
+		if strings.Contains(name, mod) {
+			return true
+		}
+	}
+	return false
 }
 
 // IsPrintable returns true if the rune is a printable character
+// (control characters such as Backspace or Escape are not)
 func IsPrintable(r rune) bool {
-	return r != 0
+	return r != 0 && unicode.IsGraphic(r)
 }
 
 // CorrectionDelay returns the recommended delay after a correction
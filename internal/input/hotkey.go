@@ -0,0 +1,134 @@
+package input
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/axide-dev/axidev-io-go/keyboard"
+)
+
+// Hotkey is a key combined with the modifiers that must be held
+type Hotkey struct {
+	Modifiers keyboard.Modifier
+	Key       keyboard.Key
+}
+
+// hotkeyModifiers maps modifier names accepted in hotkey strings
+var hotkeyModifiers = map[string]keyboard.Modifier{
+	"ctrl":    keyboard.ModCtrl,
+	"control": keyboard.ModCtrl,
+	"alt":     keyboard.ModAlt,
+	"option":  keyboard.ModAlt,
+	"shift":   keyboard.ModShift,
+	"super":   keyboard.ModSuper,
+	"cmd":     keyboard.ModSuper,
+	"meta":    keyboard.ModSuper,
+}
+
+// relevantModifiers are compared when matching, lock keys are ignored
+const relevantModifiers = keyboard.ModCtrl | keyboard.ModAlt | keyboard.ModShift | keyboard.ModSuper
+
+// ParseHotkey parses a chord such as "Ctrl+Alt+Z". An empty string yields
+// a zero Hotkey that never matches.
+func ParseHotkey(s string) (Hotkey, error) {
+	var hk Hotkey
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return hk, nil
+	}
+
+	parts := strings.Split(s, "+")
+	for i, part := range parts {
+		name := strings.TrimSpace(part)
+		if name == "" {
+			return Hotkey{}, fmt.Errorf("invalid hotkey %q: empty key name", s)
+		}
+
+		if i < len(parts)-1 {
+			mod, ok := hotkeyModifiers[strings.ToLower(name)]
+			if !ok {
+				return Hotkey{}, fmt.Errorf("invalid hotkey %q: unknown modifier %q", s, name)
+			}
+			hk.Modifiers |= mod
+			continue
+		}
+
+		hk.Key = keyboard.StringToKey(name)
+		if hk.Key == 0 {
+			return Hotkey{}, fmt.Errorf("invalid hotkey %q: unknown key %q", s, name)
+		}
+	}
+	return hk, nil
+}
+
+// ParseModifiers parses a modifier-only chord such as "Ctrl+Alt"
+func ParseModifiers(s string) (keyboard.Modifier, error) {
+	var mods keyboard.Modifier
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return mods, nil
+	}
+
+	for _, part := range strings.Split(s, "+") {
+		name := strings.TrimSpace(part)
+		mod, ok := hotkeyModifiers[strings.ToLower(name)]
+		if !ok {
+			return 0, fmt.Errorf("invalid modifiers %q: unknown modifier %q", s, name)
+		}
+		mods |= mod
+	}
+	return mods, nil
+}
+
+// DigitHotkeys returns the hotkeys mods+1 to mods+count (at most 9).
+// No modifiers yields no hotkeys, so plain digits are never captured.
+func DigitHotkeys(mods keyboard.Modifier, count int) []Hotkey {
+	if mods == 0 {
+		return nil
+	}
+
+	count = min(count, 9)
+	hotkeys := make([]Hotkey, count)
+	for i := range hotkeys {
+		hotkeys[i] = Hotkey{
+			Modifiers: mods,
+			Key:       keyboard.StringToKey(string(rune('1' + i))),
+		}
+	}
+	return hotkeys
+}
+
+// IsZero returns true if no hotkey is configured
+func (h Hotkey) IsZero() bool {
+	return h.Key == 0
+}
+
+// Matches returns true if the event is a press of the hotkey
+func (h Hotkey) Matches(event keyboard.KeyEvent) bool {
+	if h.IsZero() || !event.IsPress() {
+		return false
+	}
+	return event.Key == h.Key && event.Modifiers&relevantModifiers == h.Modifiers
+}
+
+// String returns the hotkey in the format accepted by ParseHotkey
+func (h Hotkey) String() string {
+	if h.IsZero() {
+		return ""
+	}
+
+	var parts []string
+	if h.Modifiers.HasCtrl() {
+		parts = append(parts, "Ctrl")
+	}
+	if h.Modifiers.HasAlt() {
+		parts = append(parts, "Alt")
+	}
+	if h.Modifiers.HasShift() {
+		parts = append(parts, "Shift")
+	}
+	if h.Modifiers.HasSuper() {
+		parts = append(parts, "Super")
+	}
+	return strings.Join(append(parts, keyboard.KeyToString(h.Key)), "+")
+}
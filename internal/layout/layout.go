@@ -0,0 +1,165 @@
+// Package layout describes keyboard layouts to tell which keys are next to
+// each other, so typos hitting a neighbouring key can be recognised.
+//
+// A layout description has one line per row of keys, top to bottom, with
+// the keys of a row separated by spaces. Each leading space shifts the row
+// right by half a key, following the stagger of the physical keyboard:
+//
+//	a z e r t y u i o p
+//	 q s d f g h j k l m
+//	  w x c v b n
+package layout
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// neighbourDistance is the largest distance between the centres of two
+// neighbouring keys, in key widths, covering diagonals on staggered rows
+const neighbourDistance = 1.3
+
+// Layout places the keys of a keyboard
+type Layout struct {
+	Name string
+	keys map[rune]point
+}
+
+// point is the centre of a key, in key widths from the top left key
+type point struct {
+	x, y float64
+}
+
+// Parse reads a layout description
+func Parse(name, desc string) (*Layout, error) {
+	l := &Layout{Name: name, keys: make(map[rune]point)}
+
+	row := 0
+	for _, line := range strings.Split(strings.ReplaceAll(desc, "\r\n", "\n"), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		column := 0
+		previousKey := false
+		for _, r := range line {
+			if r == ' ' {
+				column++
+				previousKey = false
+				continue
+			}
+			if previousKey {
+				return nil, fmt.Errorf("row %d: keys must be separated by spaces", row+1)
+			}
+			r = unicode.ToLower(r)
+			if _, ok := l.keys[r]; !ok {
+				l.keys[r] = point{x: float64(column) / 2, y: float64(row)}
+			}
+			column++
+			previousKey = true
+		}
+		row++
+	}
+	if len(l.keys) == 0 {
+		return nil, fmt.Errorf("layout %q has no keys", name)
+	}
+	return l, nil
+}
+
+// Load returns the built-in layout called name, or reads the description
+// file at that path
+func Load(name string) (*Layout, error) {
+	if l, ok := Builtin(name); ok {
+		return l, nil
+	}
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown keyboard layout %q: %w", name, err)
+	}
+	if !utf8.Valid(data) {
+		return nil, fmt.Errorf("keyboard layout %s is not UTF-8", name)
+	}
+	return Parse(strings.TrimSuffix(filepath.Base(name), filepath.Ext(name)), string(data))
+}
+
+// Has returns true if the layout has a key for r
+func (l *Layout) Has(r rune) bool {
+	_, ok := l.keys[unicode.ToLower(r)]
+	return ok
+}
+
+// Adjacent returns true if a and b are different keys next to each other,
+// on the same row or a neighbouring one
+func (l *Layout) Adjacent(a, b rune) bool {
+	pa, ok := l.keys[unicode.ToLower(a)]
+	if !ok {
+		return false
+	}
+	pb, ok := l.keys[unicode.ToLower(b)]
+	if !ok || pa == pb {
+		return false
+	}
+	return math.Hypot(pa.x-pb.x, pa.y-pb.y) <= neighbourDistance
+}
+
+// builtins are the descriptions of the layouts known by name. Only the
+// unshifted characters of each key are listed.
+var builtins = map[string]string{
+	"azerty": `
+& é " ' ( - è _ ç à ) =
+ a z e r t y u i o p ^ $
+  q s d f g h j k l m ù *
+ < w x c v b n , ; : !
+`,
+	"qwerty": `
+1 2 3 4 5 6 7 8 9 0 - =
+ q w e r t y u i o p [ ]
+  a s d f g h j k l ; '
+   z x c v b n m , . /
+`,
+	"qwertz": `
+1 2 3 4 5 6 7 8 9 0 ß
+ q w e r t z u i o p ü +
+  a s d f g h j k l ö ä #
+ < y x c v b n m , . -
+`,
+	"bepo": `
+" « » ( ) @ + - / * = %
+ b é p o è ^ v d l j z w
+  a u i e , c t s r n m ç
+ ê à y x . k ' q g h f
+`,
+}
+
+// Builtin returns the built-in layout called name, case-insensitively
+func Builtin(name string) (*Layout, bool) {
+	name = strings.ToLower(name)
+	if name == "bépo" {
+		name = "bepo"
+	}
+	desc, ok := builtins[name]
+	if !ok {
+		return nil, false
+	}
+	l, err := Parse(name, desc)
+	if err != nil {
+		panic(fmt.Sprintf("invalid built-in layout %s: %v", name, err))
+	}
+	return l, true
+}
+
+// Names returns the names of the built-in layouts, sorted
+func Names() []string {
+	names := make([]string, 0, len(builtins))
+	for name := range builtins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
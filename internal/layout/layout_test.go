@@ -0,0 +1,140 @@
+package layout
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	l, err := Parse("mini", "a b c\n d E f\n\n  g h\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[rune]point{
+		'a': {0, 0}, 'b': {1, 0}, 'c': {2, 0},
+		'd': {0.5, 1}, 'e': {1.5, 1}, 'f': {2.5, 1},
+		'g': {1, 2}, 'h': {2, 2},
+	}
+	if len(l.keys) != len(want) {
+		t.Fatalf("keys = %v, want %v", l.keys, want)
+	}
+	for r, p := range want {
+		if got := l.keys[r]; got != p {
+			t.Errorf("key %q at %v, want %v", r, got, p)
+		}
+	}
+	if !l.Has('E') || l.Has('z') {
+		t.Error("Has does not follow the parsed keys")
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := map[string]string{
+		"keys not separated": "ab c",
+		"no keys":            "\n  \n",
+	}
+	for name, desc := range tests {
+		if _, err := Parse("bad", desc); err == nil {
+			t.Errorf("%s: Parse(%q) succeeded", name, desc)
+		}
+	}
+}
+
+func TestAdjacent(t *testing.T) {
+	l, ok := Builtin("azerty")
+	if !ok {
+		t.Fatal("azerty is not built in")
+	}
+	tests := []struct {
+		a, b rune
+		want bool
+	}{
+		{'a', 'z', true},
+		{'z', 'a', true},
+		{'a', 'q', true},
+		{'s', 'd', true},
+		{'e', 'd', true},
+		{'m', 'ù', true},
+		{'A', 'Z', true},
+		{'a', 'e', false},
+		{'a', 'p', false},
+		{'q', 'm', false},
+		{'a', 'a', false},
+		{'a', '€', false},
+		{'€', 'a', false},
+	}
+	for _, tt := range tests {
+		if got := l.Adjacent(tt.a, tt.b); got != tt.want {
+			t.Errorf("Adjacent(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestBuiltins(t *testing.T) {
+	want := []string{"azerty", "bepo", "qwerty", "qwertz"}
+	if got := Names(); !slices.Equal(got, want) {
+		t.Fatalf("Names() = %v, want %v", got, want)
+	}
+
+	// Each layout puts its own letters side by side
+	neighbours := map[string][2]rune{
+		"azerty": {'a', 'z'},
+		"bepo":   {'b', 'é'},
+		"qwerty": {'q', 'w'},
+		"qwertz": {'t', 'z'},
+	}
+	for _, name := range want {
+		l, ok := Builtin(name)
+		if !ok {
+			t.Fatalf("Builtin(%q) not found", name)
+		}
+		if l.Name != name {
+			t.Errorf("Builtin(%q).Name = %q", name, l.Name)
+		}
+		pair := neighbours[name]
+		if !l.Adjacent(pair[0], pair[1]) {
+			t.Errorf("%s: %q and %q are not neighbours", name, pair[0], pair[1])
+		}
+	}
+
+	for _, alias := range []string{"AZERTY", "BÉPO", "bépo"} {
+		if _, ok := Builtin(alias); !ok {
+			t.Errorf("Builtin(%q) not found", alias)
+		}
+	}
+	if _, ok := Builtin("dvorak"); ok {
+		t.Error("Builtin(dvorak) found")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	if l, err := Load("qwertz"); err != nil || l.Name != "qwertz" {
+		t.Errorf("Load(qwertz) = %v, %v", l, err)
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "colemak.txt")
+	if err := os.WriteFile(path, []byte("q w f p\n a r s t\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	l, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.Name != "colemak" || !l.Adjacent('w', 'r') {
+		t.Errorf("Load(%s) = %+v", path, l)
+	}
+
+	if _, err := Load(filepath.Join(dir, "missing.txt")); err == nil {
+		t.Error("Load of a missing file succeeded")
+	}
+	latin1 := filepath.Join(dir, "latin1.txt")
+	if err := os.WriteFile(latin1, []byte("a \xe9 b\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(latin1); err == nil {
+		t.Error("Load of a non UTF-8 file succeeded")
+	}
+}
@@ -0,0 +1,192 @@
+// Package logging builds the slog logger shared by the app. Typed text is
+// logged with Secret and redacted unless redaction is turned off.
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"unicode/utf8"
+
+	"github.com/axide-dev/axidev-corrige/internal/paths"
+)
+
+// FileName is the name of the log file in paths.LogDir
+const FileName = "axidev-corrige.log"
+
+// Options configures a Logger
+type Options struct {
+	// Level is the minimum level logged
+	Level slog.Level
+	// Redact hides values logged with Secret
+	Redact bool
+	// Output receives text logs, os.Stderr if nil
+	Output io.Writer
+	// File is the path of a rotating log file, empty for none
+	File string
+}
+
+// Logger is a slog.Logger whose level and redaction can change while
+// running, for configuration reloads
+type Logger struct {
+	*slog.Logger
+	level  *slog.LevelVar
+	redact *atomic.Bool
+	file   *rotatingFile
+}
+
+// New creates a logger writing to Output and, if set, to File
+func New(opts Options) (*Logger, error) {
+	l := &Logger{
+		level:  new(slog.LevelVar),
+		redact: new(atomic.Bool),
+	}
+	l.level.Set(opts.Level)
+	l.redact.Store(opts.Redact)
+
+	handlerOpts := &slog.HandlerOptions{
+		Level:       l.level,
+		ReplaceAttr: l.replaceAttr,
+	}
+
+	output := opts.Output
+	if output == nil {
+		output = os.Stderr
+	}
+	handlers := []slog.Handler{slog.NewTextHandler(output, handlerOpts)}
+
+	if opts.File != "" {
+		file, err := openRotatingFile(opts.File, defaultMaxSize, defaultBackups)
+		if err != nil {
+			return nil, err
+		}
+		l.file = file
+		handlers = append(handlers, slog.NewJSONHandler(file, handlerOpts))
+	}
+
+	l.Logger = slog.New(fanout(handlers))
+	return l, nil
+}
+
+// Discard returns a logger that drops everything, for callers without one
+func Discard() *Logger {
+	return &Logger{
+		Logger: slog.New(slog.DiscardHandler),
+		level:  new(slog.LevelVar),
+		redact: new(atomic.Bool),
+	}
+}
+
+// DefaultFile returns the log file location in the user data directory
+func DefaultFile() (string, error) {
+	dir, err := paths.LogDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, FileName), nil
+}
+
+// SetLevel changes the minimum level logged
+func (l *Logger) SetLevel(level slog.Level) {
+	l.level.Set(level)
+}
+
+// SetRedact turns redaction of Secret values on or off
+func (l *Logger) SetRedact(redact bool) {
+	l.redact.Store(redact)
+}
+
+// Close closes the log file, if any
+func (l *Logger) Close() error {
+	if l.file == nil {
+		return nil
+	}
+	return l.file.Close()
+}
+
+// ParseLevel parses debug, info, warn or error
+func ParseLevel(s string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.TrimSpace(s))); err != nil {
+		return 0, fmt.Errorf("unknown log level %q", s)
+	}
+	return level, nil
+}
+
+// secret is typed text that must not reach logs unless redaction is off.
+// It is resolved by the handler's ReplaceAttr, so other handlers only see
+// its String form.
+type secret string
+
+// String returns the redacted form
+func (s secret) String() string {
+	return redacted(string(s))
+}
+
+// Secret returns an attribute for text the user typed
+func Secret(key, value string) slog.Attr {
+	return slog.Any(key, secret(value))
+}
+
+func (l *Logger) replaceAttr(_ []string, a slog.Attr) slog.Attr {
+	if a.Value.Kind() != slog.KindAny {
+		return a
+	}
+	s, ok := a.Value.Any().(secret)
+	if !ok {
+		return a
+	}
+	if l.redact.Load() {
+		return slog.String(a.Key, redacted(string(s)))
+	}
+	return slog.String(a.Key, string(s))
+}
+
+// redacted keeps only the length of s
+func redacted(s string) string {
+	return fmt.Sprintf("[%d chars]", utf8.RuneCountInString(s))
+}
+
+// fanout sends records to every handler enabled for their level
+type fanout []slog.Handler
+
+func (f fanout) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, h := range f {
+		if h.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f fanout) Handle(ctx context.Context, r slog.Record) error {
+	var errs []error
+	for _, h := range f {
+		if h.Enabled(ctx, r.Level) {
+			errs = append(errs, h.Handle(ctx, r.Clone()))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (f fanout) WithAttrs(attrs []slog.Attr) slog.Handler {
+	next := make(fanout, len(f))
+	for i, h := range f {
+		next[i] = h.WithAttrs(attrs)
+	}
+	return next
+}
+
+func (f fanout) WithGroup(name string) slog.Handler {
+	next := make(fanout, len(f))
+	for i, h := range f {
+		next[i] = h.WithGroup(name)
+	}
+	return next
+}
@@ -0,0 +1,52 @@
+package lsp
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestConnRead(t *testing.T) {
+	body := `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`
+	input := "Content-Length: " + strconv.Itoa(len(body)) + "\r\nContent-Type: application/vscode-jsonrpc; charset=utf-8\r\n\r\n" + body
+	msg, err := newConn(strings.NewReader(input), nil).read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Method != "initialize" || msg.isNotification() {
+		t.Errorf("message = %+v", msg)
+	}
+}
+
+func TestConnReadRejects(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"missing length", "Content-Type: text/plain\r\n\r\n{}"},
+		{"negative length", "Content-Length: -1\r\n\r\n{}"},
+		{"not a number", "Content-Length: many\r\n\r\n{}"},
+		{"too large", "Content-Length: 17000000\r\n\r\n{}"},
+		{"short body", "Content-Length: 10\r\n\r\n{}"},
+	}
+	for _, tt := range tests {
+		if _, err := newConn(strings.NewReader(tt.input), nil).read(); err == nil {
+			t.Errorf("%s: read succeeded", tt.name)
+		}
+	}
+}
+
+func TestConnWrite(t *testing.T) {
+	var out bytes.Buffer
+	if err := newConn(nil, &out).notify("window/logMessage", map[string]string{"message": "é"}); err != nil {
+		t.Fatal(err)
+	}
+	msg, err := newConn(&out, nil).read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.JSONRPC != "2.0" || msg.Method != "window/logMessage" || string(msg.Params) != `{"message":"é"}` {
+		t.Errorf("message = %+v", msg)
+	}
+}
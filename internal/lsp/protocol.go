@@ -0,0 +1,145 @@
+package lsp
+
+import (
+	"unicode/utf8"
+)
+
+// The subset of LSP types the server uses
+
+type position struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+type lspRange struct {
+	Start position `json:"start"`
+	End   position `json:"end"`
+}
+
+type textDocumentItem struct {
+	URI  string `json:"uri"`
+	Text string `json:"text"`
+}
+
+type textDocumentIdentifier struct {
+	URI string `json:"uri"`
+}
+
+type didOpenParams struct {
+	TextDocument textDocumentItem `json:"textDocument"`
+}
+
+type didChangeParams struct {
+	TextDocument   textDocumentIdentifier `json:"textDocument"`
+	ContentChanges []struct {
+		Text string `json:"text"`
+	} `json:"contentChanges"`
+}
+
+type didCloseParams struct {
+	TextDocument textDocumentIdentifier `json:"textDocument"`
+}
+
+// diagnosticSeverityInformation keeps misspellings below compiler errors
+const diagnosticSeverityInformation = 3
+
+type diagnostic struct {
+	Range    lspRange       `json:"range"`
+	Severity int            `json:"severity,omitempty"`
+	Source   string         `json:"source"`
+	Message  string         `json:"message"`
+	Data     diagnosticData `json:"data"`
+}
+
+// diagnosticData is sent back in code action requests
+type diagnosticData struct {
+	Word        string   `json:"word"`
+	Suggestions []string `json:"suggestions"`
+}
+
+type publishDiagnosticsParams struct {
+	URI         string       `json:"uri"`
+	Diagnostics []diagnostic `json:"diagnostics"`
+}
+
+type codeActionParams struct {
+	TextDocument textDocumentIdentifier `json:"textDocument"`
+	Context      struct {
+		Diagnostics []diagnostic `json:"diagnostics"`
+	} `json:"context"`
+}
+
+type textEdit struct {
+	Range   lspRange `json:"range"`
+	NewText string   `json:"newText"`
+}
+
+type workspaceEdit struct {
+	Changes map[string][]textEdit `json:"changes"`
+}
+
+type command struct {
+	Title     string `json:"title"`
+	Command   string `json:"command"`
+	Arguments []any  `json:"arguments"`
+}
+
+type codeAction struct {
+	Title       string         `json:"title"`
+	Kind        string         `json:"kind"`
+	Diagnostics []diagnostic   `json:"diagnostics,omitempty"`
+	IsPreferred bool           `json:"isPreferred,omitempty"`
+	Edit        *workspaceEdit `json:"edit,omitempty"`
+	Command     *command       `json:"command,omitempty"`
+}
+
+type executeCommandParams struct {
+	Command   string   `json:"command"`
+	Arguments []string `json:"arguments"`
+}
+
+// lineIndex converts byte offsets of a text to LSP positions, whose
+// characters are UTF-16 code units
+type lineIndex struct {
+	text  string
+	lines []int
+}
+
+func newLineIndex(text string) *lineIndex {
+	lines := []int{0}
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\n' {
+			lines = append(lines, i+1)
+		}
+	}
+	return &lineIndex{text: text, lines: lines}
+}
+
+// position returns the position of the byte offset
+func (l *lineIndex) position(offset int) position {
+	// Last line starting at or before offset
+	line := 0
+	for lo, hi := 0, len(l.lines)-1; lo <= hi; {
+		mid := (lo + hi) / 2
+		if l.lines[mid] <= offset {
+			line = mid
+			lo = mid + 1
+		} else {
+			hi = mid - 1
+		}
+	}
+
+	character := 0
+	for _, r := range l.text[l.lines[line]:offset] {
+		character += utf16Len(r)
+	}
+	return position{Line: line, Character: character}
+}
+
+// utf16Len returns the number of UTF-16 code units encoding r
+func utf16Len(r rune) int {
+	if r >= 0x10000 && r <= utf8.MaxRune {
+		return 2
+	}
+	return 1
+}
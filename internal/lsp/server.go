@@ -0,0 +1,340 @@
+// Package lsp is a Language Server Protocol server over stdio publishing
+// misspellings as diagnostics, with suggestions as code actions
+package lsp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/axide-dev/axidev-corrige/internal/checker"
+)
+
+// Commands offered as code actions
+const (
+	CommandAddToDictionary = "axidev-corrige.addToDictionary"
+	CommandIgnoreWord      = "axidev-corrige.ignoreWord"
+)
+
+// diagnosticSource labels the server's diagnostics in editors
+const diagnosticSource = "axidev-corrige"
+
+// ErrNoShutdown is returned by Run when the client sent exit without
+// shutdown first, for which the protocol asks for exit status 1
+var ErrNoShutdown = errors.New("exit without shutdown")
+
+// maxSuggestions is the number of suggestions offered per word
+const maxSuggestions = 5
+
+// DetectorLoader loads the checkers with the personal dictionary merged.
+// The server calls it again when the personal dictionary file changes, so
+// words added from the desktop app are picked up.
+type DetectorLoader func() (*checker.Detector, *checker.PersonalDictionary, error)
+
+// Server handles one editor session
+type Server struct {
+	conn *conn
+	load DetectorLoader
+	log  *slog.Logger
+
+	detector *checker.Detector
+	personal *checker.PersonalDictionary
+	// personalModTime is the personal dictionary file time when loaded
+	personalModTime time.Time
+
+	docs     map[string]string
+	shutdown bool
+}
+
+// NewServer creates a server reading requests from r and writing to w
+func NewServer(r io.Reader, w io.Writer, load DetectorLoader, log *slog.Logger) *Server {
+	return &Server{
+		conn: newConn(r, w),
+		load: load,
+		log:  log,
+		docs: make(map[string]string),
+	}
+}
+
+// Run serves requests until exit. Returns an error if the input ended
+// or exit came without shutdown first.
+func (s *Server) Run() error {
+	if err := s.reload(); err != nil {
+		return err
+	}
+
+	for {
+		msg, err := s.conn.read()
+		var rerr *rpcError
+		switch {
+		case errors.As(err, &rerr):
+			s.log.Warn("Invalid message", "err", err)
+			continue
+		case err != nil:
+			return err
+		}
+
+		if msg.Method == "exit" {
+			if !s.shutdown {
+				return ErrNoShutdown
+			}
+			return nil
+		}
+
+		result, err := s.handle(msg)
+		if msg.isNotification() {
+			if err != nil {
+				s.log.Warn("Notification failed", "method", msg.Method, "err", err)
+			}
+			continue
+		}
+		if err := s.conn.reply(msg.ID, result, err); err != nil {
+			return err
+		}
+	}
+}
+
+// handle dispatches a request or notification
+func (s *Server) handle(msg *message) (any, error) {
+	switch msg.Method {
+	case "initialize":
+		return s.initialize(), nil
+	case "initialized", "textDocument/didSave", "$/cancelRequest", "$/setTrace":
+		return nil, nil
+	case "shutdown":
+		s.shutdown = true
+		return nil, nil
+	case "textDocument/didOpen":
+		var params didOpenParams
+		if err := decodeParams(msg, &params); err != nil {
+			return nil, err
+		}
+		s.docs[params.TextDocument.URI] = params.TextDocument.Text
+		return nil, s.publish(params.TextDocument.URI)
+	case "textDocument/didChange":
+		var params didChangeParams
+		if err := decodeParams(msg, &params); err != nil {
+			return nil, err
+		}
+		if n := len(params.ContentChanges); n > 0 {
+			// Full sync: the last change holds the whole text
+			s.docs[params.TextDocument.URI] = params.ContentChanges[n-1].Text
+		}
+		return nil, s.publish(params.TextDocument.URI)
+	case "textDocument/didClose":
+		var params didCloseParams
+		if err := decodeParams(msg, &params); err != nil {
+			return nil, err
+		}
+		delete(s.docs, params.TextDocument.URI)
+		return nil, s.conn.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{
+			URI:         params.TextDocument.URI,
+			Diagnostics: []diagnostic{},
+		})
+	case "textDocument/codeAction":
+		var params codeActionParams
+		if err := decodeParams(msg, &params); err != nil {
+			return nil, err
+		}
+		return s.codeActions(params), nil
+	case "workspace/executeCommand":
+		var params executeCommandParams
+		if err := decodeParams(msg, &params); err != nil {
+			return nil, err
+		}
+		return nil, s.executeCommand(params)
+	default:
+		return nil, &rpcError{Code: codeMethodNotFound, Message: "method not found: " + msg.Method}
+	}
+}
+
+func decodeParams(msg *message, v any) error {
+	if err := json.Unmarshal(msg.Params, v); err != nil {
+		return &rpcError{Code: codeInvalidParams, Message: err.Error()}
+	}
+	return nil
+}
+
+// initialize advertises full document sync, code actions and commands
+func (s *Server) initialize() any {
+	return map[string]any{
+		"capabilities": map[string]any{
+			"textDocumentSync": map[string]any{
+				"openClose": true,
+				"change":    1,
+			},
+			"codeActionProvider": map[string]any{
+				"codeActionKinds": []string{"quickfix"},
+			},
+			"executeCommandProvider": map[string]any{
+				"commands": []string{CommandAddToDictionary, CommandIgnoreWord},
+			},
+		},
+		"serverInfo": map[string]string{"name": diagnosticSource},
+	}
+}
+
+// publish checks a document and sends its diagnostics
+func (s *Server) publish(uri string) error {
+	if err := s.reloadIfChanged(); err != nil {
+		s.log.Warn("Personal dictionary not reloaded", "err", err)
+	}
+
+	text := s.docs[uri]
+	index := newLineIndex(text)
+	diagnostics := []diagnostic{}
+	for _, m := range s.detector.CheckText(text, maxSuggestions) {
+		word := m.Token.Parts.Word
+		suggestions := make([]string, len(m.Suggestions))
+		for i, sug := range m.Suggestions {
+			suggestions[i] = sug.Value
+		}
+
+		message := fmt.Sprintf("Unknown word: %s", word)
+		if len(suggestions) > 0 {
+			message += fmt.Sprintf(" (%s)", strings.Join(suggestions, ", "))
+		}
+		diagnostics = append(diagnostics, diagnostic{
+			Range: lspRange{
+				Start: index.position(m.Token.Offset),
+				End:   index.position(m.Token.Offset + len(word)),
+			},
+			Severity: diagnosticSeverityInformation,
+			Source:   diagnosticSource,
+			Message:  message,
+			Data:     diagnosticData{Word: word, Suggestions: suggestions},
+		})
+	}
+
+	return s.conn.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{
+		URI:         uri,
+		Diagnostics: diagnostics,
+	})
+}
+
+// publishAll refreshes the diagnostics of every open document
+func (s *Server) publishAll() error {
+	uris := make([]string, 0, len(s.docs))
+	for uri := range s.docs {
+		uris = append(uris, uri)
+	}
+	sort.Strings(uris)
+
+	var errs []error
+	for _, uri := range uris {
+		errs = append(errs, s.publish(uri))
+	}
+	return errors.Join(errs...)
+}
+
+// codeActions offers each suggestion as a quick fix, then adding or
+// ignoring the word
+func (s *Server) codeActions(params codeActionParams) []codeAction {
+	actions := []codeAction{}
+	for _, d := range params.Context.Diagnostics {
+		if d.Source != diagnosticSource || d.Data.Word == "" {
+			continue
+		}
+
+		for i, suggestion := range d.Data.Suggestions {
+			actions = append(actions, codeAction{
+				Title:       fmt.Sprintf("Replace with '%s'", suggestion),
+				Kind:        "quickfix",
+				Diagnostics: []diagnostic{d},
+				IsPreferred: i == 0,
+				Edit: &workspaceEdit{Changes: map[string][]textEdit{
+					params.TextDocument.URI: {{Range: d.Range, NewText: suggestion}},
+				}},
+			})
+		}
+		actions = append(actions,
+			codeAction{
+				Title:       fmt.Sprintf("Add '%s' to dictionary", d.Data.Word),
+				Kind:        "quickfix",
+				Diagnostics: []diagnostic{d},
+				Command: &command{
+					Title:     "Add to dictionary",
+					Command:   CommandAddToDictionary,
+					Arguments: []any{d.Data.Word},
+				},
+			},
+			codeAction{
+				Title:       fmt.Sprintf("Ignore '%s'", d.Data.Word),
+				Kind:        "quickfix",
+				Diagnostics: []diagnostic{d},
+				Command: &command{
+					Title:     "Ignore word",
+					Command:   CommandIgnoreWord,
+					Arguments: []any{d.Data.Word},
+				},
+			},
+		)
+	}
+	return actions
+}
+
+// executeCommand adds or ignores a word in the shared personal dictionary
+// and refreshes the diagnostics
+func (s *Server) executeCommand(params executeCommandParams) error {
+	if len(params.Arguments) != 1 || strings.TrimSpace(params.Arguments[0]) == "" {
+		return &rpcError{Code: codeInvalidParams, Message: "expected one word argument"}
+	}
+	word := strings.TrimSpace(params.Arguments[0])
+
+	switch params.Command {
+	case CommandAddToDictionary:
+		if err := s.personal.Add(word); err != nil {
+			return fmt.Errorf("failed to save personal dictionary: %w", err)
+		}
+		for _, chk := range s.detector.Checkers() {
+			chk.Learn(word)
+		}
+	case CommandIgnoreWord:
+		if err := s.personal.Ignore(word); err != nil {
+			return fmt.Errorf("failed to save personal dictionary: %w", err)
+		}
+	default:
+		return &rpcError{Code: codeInvalidParams, Message: "unknown command: " + params.Command}
+	}
+
+	s.personalModTime = s.personalFileTime()
+	return s.publishAll()
+}
+
+// reload loads the checkers and personal dictionary
+func (s *Server) reload() error {
+	detector, personal, err := s.load()
+	if err != nil {
+		return err
+	}
+	s.detector = detector
+	s.personal = personal
+	s.personalModTime = s.personalFileTime()
+	return nil
+}
+
+// reloadIfChanged reloads when another process, such as the desktop app,
+// changed the personal dictionary file
+func (s *Server) reloadIfChanged() error {
+	if s.personalFileTime().Equal(s.personalModTime) {
+		return nil
+	}
+	s.log.Info("Personal dictionary changed, reloading")
+	return s.reload()
+}
+
+// personalFileTime returns the modification time of the personal
+// dictionary file, zero if it does not exist
+func (s *Server) personalFileTime() time.Time {
+	info, err := os.Stat(s.personal.Path())
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
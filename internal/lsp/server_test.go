@@ -0,0 +1,161 @@
+package lsp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/axide-dev/axidev-corrige/internal/checker"
+)
+
+// client drives a server over in-memory pipes
+type client struct {
+	t    *testing.T
+	in   *io.PipeWriter
+	out  *conn
+	done chan error
+}
+
+// startServer runs a server over the embedded French dictionary
+func startServer(t *testing.T) *client {
+	t.Helper()
+	// Use the embedded dictionary, not one installed by the user
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_DATA_HOME", filepath.Join(home, "data"))
+
+	load := func() (*checker.Detector, *checker.PersonalDictionary, error) {
+		chk, err := checker.NewChecker("fr")
+		if err != nil {
+			return nil, nil, err
+		}
+		personal, err := checker.LoadPersonalDictionary(filepath.Join(home, "personal.json"))
+		if err != nil {
+			return nil, nil, err
+		}
+		chk.SetPersonal(personal)
+		return checker.NewDetector([]*checker.Checker{chk}, 0), personal, nil
+	}
+
+	serverIn, clientOut := io.Pipe()
+	clientIn, serverOut := io.Pipe()
+	c := &client{t: t, in: clientOut, out: newConn(clientIn, nil), done: make(chan error, 1)}
+	server := NewServer(serverIn, serverOut, load, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	go func() {
+		c.done <- server.Run()
+		serverOut.Close()
+	}()
+	t.Cleanup(func() { clientOut.Close() })
+	return c
+}
+
+// send writes a framed message with the given id, or a notification when
+// id is zero
+func (c *client) send(id int, method string, params any) {
+	c.t.Helper()
+	msg := map[string]any{"jsonrpc": "2.0", "method": method}
+	if id != 0 {
+		msg["id"] = id
+	}
+	if params != nil {
+		msg["params"] = params
+	}
+	body, err := json.Marshal(msg)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	if _, err := fmt.Fprintf(c.in, "Content-Length: %d\r\n\r\n%s", len(body), body); err != nil {
+		c.t.Fatal(err)
+	}
+}
+
+// receive reads the next message sent by the server
+func (c *client) receive() *message {
+	c.t.Helper()
+	msg, err := c.out.read()
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	return msg
+}
+
+// wait returns what Run returned
+func (c *client) wait() error {
+	c.t.Helper()
+	select {
+	case err := <-c.done:
+		return err
+	case <-time.After(5 * time.Second):
+		c.t.Fatal("server did not stop")
+		return nil
+	}
+}
+
+func TestServerRoundTrip(t *testing.T) {
+	c := startServer(t)
+
+	c.send(1, "initialize", map[string]any{"capabilities": map[string]any{}})
+	resp := c.receive()
+	if resp.ID == nil || string(*resp.ID) != "1" || resp.Error != nil {
+		t.Fatalf("initialize response = %+v", resp)
+	}
+	c.send(0, "initialized", map[string]any{})
+
+	const uri = "file:///tmp/notes.txt"
+	c.send(0, "textDocument/didOpen", map[string]any{
+		"textDocument": map[string]any{"uri": uri, "languageId": "plaintext", "version": 1, "text": "le monde\nun été chaudd"},
+	})
+	note := c.receive()
+	if note.Method != "textDocument/publishDiagnostics" {
+		t.Fatalf("got %+v, want diagnostics", note)
+	}
+	var params publishDiagnosticsParams
+	if err := json.Unmarshal(note.Params, &params); err != nil {
+		t.Fatal(err)
+	}
+	if params.URI != uri || len(params.Diagnostics) != 1 {
+		t.Fatalf("diagnostics = %+v", params)
+	}
+	d := params.Diagnostics[0]
+	want := lspRange{Start: position{Line: 1, Character: 7}, End: position{Line: 1, Character: 13}}
+	if d.Data.Word != "chaudd" || d.Range != want || d.Source != diagnosticSource {
+		t.Errorf("diagnostic = %+v, want chaudd at %+v", d, want)
+	}
+	if len(d.Data.Suggestions) == 0 || d.Data.Suggestions[0] != "chaud" {
+		t.Errorf("suggestions = %v, want chaud first", d.Data.Suggestions)
+	}
+
+	c.send(2, "shutdown", nil)
+	resp = c.receive()
+	if resp.ID == nil || string(*resp.ID) != "2" || resp.Error != nil {
+		t.Fatalf("shutdown response = %+v", resp)
+	}
+	c.send(0, "exit", nil)
+	if err := c.wait(); err != nil {
+		t.Errorf("Run() = %v after shutdown and exit", err)
+	}
+}
+
+func TestServerExitWithoutShutdown(t *testing.T) {
+	c := startServer(t)
+	c.send(0, "exit", nil)
+	if err := c.wait(); !errors.Is(err, ErrNoShutdown) {
+		t.Errorf("Run() = %v, want ErrNoShutdown", err)
+	}
+}
+
+func TestServerUnknownMethod(t *testing.T) {
+	c := startServer(t)
+	c.send(1, "textDocument/hover", map[string]any{})
+	resp := c.receive()
+	if resp.Error == nil || resp.Error.Code != codeMethodNotFound {
+		t.Errorf("response = %+v, want method not found", resp)
+	}
+	c.send(0, "exit", nil)
+	c.wait()
+}
@@ -0,0 +1,117 @@
+// Package ngram is a word trigram language model with stupid backoff, used
+// to tell which of several candidate words best follows the words before
+// it.
+//
+// Models are read from text files with one n-gram of one to three words
+// per line followed by a tab and its count:
+//
+//	la	1520
+//	la mer	84
+//	à la mer	61
+package ngram
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// MaxOrder is the longest n-gram the model uses
+const MaxOrder = 3
+
+// backoff is the factor applied each time a shorter n-gram is used
+const backoff = 0.4
+
+// Model holds n-gram counts
+type Model struct {
+	counts map[string]uint64
+	// total is the sum of the unigram counts
+	total uint64
+}
+
+// Load reads a model file
+func Load(path string) (*Model, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	m, err := Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("invalid n-gram model %s: %w", path, err)
+	}
+	return m, nil
+}
+
+// Parse reads a model in the file format. Counts of repeated n-grams add
+// up, empty lines and lines starting with # are skipped.
+func Parse(r io.Reader) (*Model, error) {
+	m := &Model{counts: make(map[string]uint64)}
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		gram, count, ok := strings.Cut(text, "\t")
+		if !ok {
+			return nil, fmt.Errorf("line %d: missing tab before the count", line)
+		}
+		n, err := strconv.ParseUint(strings.TrimSpace(count), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid count %q", line, count)
+		}
+		words := strings.Fields(strings.ToLower(gram))
+		if len(words) == 0 || len(words) > MaxOrder {
+			return nil, fmt.Errorf("line %d: expected 1 to %d words, got %d", line, MaxOrder, len(words))
+		}
+
+		m.counts[strings.Join(words, " ")] += n
+		if len(words) == 1 {
+			m.total += n
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Len returns the number of distinct n-grams in the model
+func (m *Model) Len() int {
+	return len(m.counts)
+}
+
+// Score returns the stupid backoff score of word following the last words
+// of context: the relative frequency of the longest n-gram seen, reduced
+// for each word of context dropped. Words never seen score 0.
+func (m *Model) Score(context []string, word string) float64 {
+	word = strings.ToLower(word)
+	if len(context) > MaxOrder-1 {
+		context = context[len(context)-(MaxOrder-1):]
+	}
+
+	factor := 1.0
+	for i := range context {
+		history := strings.ToLower(strings.Join(context[i:], " "))
+		if seen := m.counts[history]; seen > 0 {
+			if n := m.counts[history+" "+word]; n > 0 {
+				return factor * float64(n) / float64(seen)
+			}
+		}
+		factor *= backoff
+	}
+
+	if m.total == 0 {
+		return 0
+	}
+	return factor * float64(m.counts[word]) / float64(m.total)
+}
@@ -0,0 +1,112 @@
+package ngram
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testModel = `# counts from a tiny corpus
+la	100
+mer	20
+terre	30
+à	50
+la mer	10
+la terre	5
+à la mer	4
+À La	8
+`
+
+func parse(t *testing.T, text string) *Model {
+	t.Helper()
+	m, err := Parse(strings.NewReader(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestParse(t *testing.T) {
+	m := parse(t, testModel+"\nla\t50\n")
+	if m.Len() != 8 {
+		t.Errorf("Len() = %d, want 8", m.Len())
+	}
+	if got := m.counts["la"]; got != 150 {
+		t.Errorf("repeated unigram count = %d, want 150", got)
+	}
+	if got := m.counts["à la"]; got != 8 {
+		t.Errorf("uppercase bigram count = %d, want 8", got)
+	}
+	if m.total != 250 {
+		t.Errorf("total = %d, want the unigram sum 250", m.total)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []string{
+		"la 100",
+		"la\tmany",
+		"la\t-1",
+		"\t3",
+		"à la mer bleue\t2",
+	}
+	for _, text := range tests {
+		if _, err := Parse(strings.NewReader(text)); err == nil {
+			t.Errorf("Parse(%q) succeeded", text)
+		}
+	}
+}
+
+func TestScore(t *testing.T) {
+	m := parse(t, testModel)
+
+	tests := []struct {
+		name    string
+		context []string
+		word    string
+		want    float64
+	}{
+		{"trigram hit", []string{"à", "la"}, "mer", 4.0 / 8},
+		{"bigram backoff", []string{"à", "la"}, "terre", backoff * 5 / 100},
+		{"unigram backoff", []string{"vers", "le"}, "terre", backoff * backoff * 30 / 200},
+		{"no context", nil, "mer", 20.0 / 200},
+		{"unseen word", []string{"à", "la"}, "montagne", 0},
+		{"case ignored", []string{"À", "LA"}, "Mer", 4.0 / 8},
+		{"only the last two words count", []string{"sous", "le", "ciel", "à", "la"}, "mer", 4.0 / 8},
+	}
+	for _, tt := range tests {
+		if got := m.Score(tt.context, tt.word); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: Score(%q, %q) = %v, want %v", tt.name, tt.context, tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestScoreEmptyModel(t *testing.T) {
+	m := parse(t, "")
+	if got := m.Score([]string{"la"}, "mer"); got != 0 {
+		t.Errorf("Score() = %v on an empty model", got)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fr.ngram")
+	if err := os.WriteFile(path, []byte(testModel), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Len() != 8 {
+		t.Errorf("Len() = %d, want 8", m.Len())
+	}
+
+	if err := os.WriteFile(path, []byte("la\tmany\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), path) {
+		t.Errorf("Load() error %v does not name the file", err)
+	}
+}
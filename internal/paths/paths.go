@@ -0,0 +1,53 @@
+package paths
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// AppName is the directory name used under the OS config and data roots
+const AppName = "axidev-corrige"
+
+// ConfigDir returns the per-user configuration directory for the app
+func ConfigDir() (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, AppName), nil
+}
+
+// DataDir returns the per-user data directory for the app.
+// On Linux it follows XDG_DATA_HOME, elsewhere it lives next to the config.
+func DataDir() (string, error) {
+	if runtime.GOOS == "linux" {
+		if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+			return filepath.Join(dir, AppName), nil
+		}
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(home, ".local", "share", AppName), nil
+	}
+	return ConfigDir()
+}
+
+// DictionaryDir returns the directory scanned for user-supplied dictionaries
+func DictionaryDir() (string, error) {
+	dir, err := DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "dictionaries"), nil
+}
+
+// LogDir returns the directory holding the optional log file
+func LogDir() (string, error) {
+	dir, err := DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "logs"), nil
+}
@@ -0,0 +1,139 @@
+// Package secure detects password entry, so keystrokes typed into secure
+// fields are neither buffered nor logged.
+package secure
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/axide-dev/axidev-corrige/internal/tokenize"
+)
+
+// Reason tells why input was considered sensitive
+type Reason string
+
+const (
+	// ReasonNone - nothing suggests a secure field
+	ReasonNone Reason = ""
+	// ReasonSecureInput - the platform reports a secure text field
+	ReasonSecureInput Reason = "secure input"
+	// ReasonWindowTitle - the focused window asks for credentials
+	ReasonWindowTitle Reason = "window title"
+	// ReasonPasswordLike - the word being typed looks like a password
+	ReasonPasswordLike Reason = "password-like input"
+)
+
+// titleKeywords are lowercase fragments of window titles asking for
+// credentials
+var titleKeywords = []string{
+	"password", "passphrase", "passwort", "contraseña",
+	"mot de passe", "code pin", "pin code",
+	"sign in", "sign-in", "log in", "login", "se connecter", "connexion",
+	"authentication", "authentification", "unlock", "déverrouill",
+}
+
+// InputEnabled returns true if the platform reports that a secure text
+// field has keyboard focus. Only macOS exposes this.
+func InputEnabled() bool {
+	return secureInputEnabled()
+}
+
+// SensitiveTitle returns true if a window title suggests a credentials
+// prompt
+func SensitiveTitle(title string) bool {
+	title = strings.ToLower(title)
+	for _, keyword := range titleKeywords {
+		if strings.Contains(title, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// minPasswordLength is the length from which a word is tested by
+// LooksLikePassword
+const minPasswordLength = 8
+
+// LooksLikePassword returns true for a word mixing at least three of
+// lowercase, uppercase, digits and symbols with a digit or symbol among
+// them, which prose words almost never do. Apostrophes and hyphens are
+// not symbols, so elisions and compounds do not match. Punctuation around
+// the word, as in "Bonjour." or "(Attention)", is ignored; punctuation
+// inside it, as in "hunter2.Blue", counts as symbols.
+func LooksLikePassword(word string) bool {
+	word = strings.TrimFunc(word, tokenize.IsSeparator)
+	if utf8.RuneCountInString(word) < minPasswordLength {
+		return false
+	}
+
+	var lower, upper, digit, symbol bool
+	for _, r := range word {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		case r == '\'' || r == '’' || r == '-':
+		default:
+			symbol = true
+		}
+	}
+
+	classes := 0
+	for _, set := range []bool{lower, upper, digit, symbol} {
+		if set {
+			classes++
+		}
+	}
+	return classes >= 3 && (digit || symbol)
+}
+
+// Run is the raw keystroke run since the last whitespace. Passwords are
+// typed without spaces, so the run holds them whole even when punctuation
+// ends words in the middle of one.
+type Run struct {
+	text []rune
+}
+
+// Add appends key to the run. Whitespace ends the run and starts a new
+// one.
+func (r *Run) Add(key rune) {
+	if unicode.IsSpace(key) {
+		r.Reset()
+		return
+	}
+	r.text = append(r.text, key)
+}
+
+// Backspace removes the last key of the run
+func (r *Run) Backspace() {
+	if len(r.text) > 0 {
+		r.text = r.text[:len(r.text)-1]
+	}
+}
+
+// Reset empties the run, once the caret left it
+func (r *Run) Reset() {
+	r.text = r.text[:0]
+}
+
+// String returns the keys of the run
+func (r *Run) String() string {
+	return string(r.text)
+}
+
+// LooksLikePassword returns true if the run looks like a password
+func (r *Run) LooksLikePassword() bool {
+	return LooksLikePassword(r.String())
+}
+
+// Split returns true once a separator sits inside the run, as in
+// "hunter2.Blue" or "example.com", so the words around it are not prose.
+// Opening and trailing punctuation, as in "(voir" or "fin.)", does not
+// split the run.
+func (r *Run) Split() bool {
+	return strings.IndexFunc(strings.TrimFunc(r.String(), tokenize.IsSeparator), tokenize.IsSeparator) >= 0
+}
@@ -0,0 +1,15 @@
+//go:build darwin && cgo
+
+package secure
+
+/*
+#cgo LDFLAGS: -framework Carbon
+#include <Carbon/Carbon.h>
+*/
+import "C"
+
+// secureInputEnabled asks the HIToolbox whether an application enabled
+// secure event input, as password fields do while focused
+func secureInputEnabled() bool {
+	return C.IsSecureEventInputEnabled() != 0
+}
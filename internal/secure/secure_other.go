@@ -0,0 +1,8 @@
+//go:build !darwin || !cgo
+
+package secure
+
+// secureInputEnabled returns false: secure fields are not exposed here
+func secureInputEnabled() bool {
+	return false
+}
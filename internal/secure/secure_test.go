@@ -0,0 +1,102 @@
+package secure
+
+import "testing"
+
+func TestLooksLikePassword(t *testing.T) {
+	tests := []struct {
+		word string
+		want bool
+	}{
+		{"Tr0ub4dor", true},
+		{"hunter2.Blue!", true},
+		{"hunter2.Blue", true},
+		{"p@ssw0rd", true},
+		{"correct/horse7", true},
+		{"Summer24!", true},
+		{"(Password1)", true},
+		{"Short1!", false},
+		{"hunter2!", false},
+		{"Bonjour.", false},
+		{"Aujourd'hui,", false},
+		{"(Attention)", false},
+		{"«Bienvenue»", false},
+		{"Saint-Étienne", false},
+		{"Jusqu’aujourd’hui", false},
+		{"anticonstitutionnellement", false},
+		{"2024-2025", false},
+		{"...", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := LooksLikePassword(tt.word); got != tt.want {
+			t.Errorf("LooksLikePassword(%q) = %v, want %v", tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		keys     string
+		text     string
+		split    bool
+		password bool
+	}{
+		{"bonjour", "bonjour", false, false},
+		{"Bonjour le monde", "monde", false, false},
+		{"fin.)", "fin.)", false, false},
+		{"(voir", "(voir", false, false},
+		{"l'exemple", "l'exemple", false, false},
+		{"hunter2.", "hunter2.", false, false},
+		{"hunter2.B", "hunter2.B", true, true},
+		{"mot hunter2.Blue!", "hunter2.Blue!", true, true},
+		{"example.com", "example.com", true, false},
+		{"hunter2.Blue! suite", "suite", false, false},
+		{"a\tb\nc", "c", false, false},
+	}
+	for _, tt := range tests {
+		var r Run
+		for _, key := range tt.keys {
+			r.Add(key)
+		}
+		if got := r.String(); got != tt.text {
+			t.Errorf("%q: run = %q, want %q", tt.keys, got, tt.text)
+		}
+		if got := r.Split(); got != tt.split {
+			t.Errorf("%q: Split() = %v, want %v", tt.keys, got, tt.split)
+		}
+		if got := r.LooksLikePassword(); got != tt.password {
+			t.Errorf("%q: LooksLikePassword() = %v, want %v", tt.keys, got, tt.password)
+		}
+	}
+}
+
+func TestRunBackspace(t *testing.T) {
+	var r Run
+	for _, key := range "site.fr" {
+		r.Add(key)
+	}
+	for range 3 {
+		r.Backspace()
+	}
+	if r.String() != "site" || r.Split() {
+		t.Errorf("run = %q, split %v after erasing the punctuation", r.String(), r.Split())
+	}
+	r.Reset()
+	r.Backspace()
+	if r.String() != "" {
+		t.Errorf("run = %q after Reset", r.String())
+	}
+}
+
+func TestSensitiveTitle(t *testing.T) {
+	for _, title := range []string{"Enter Password", "Mot de passe requis", "Sign in - Google", "Déverrouiller le trousseau"} {
+		if !SensitiveTitle(title) {
+			t.Errorf("SensitiveTitle(%q) = false", title)
+		}
+	}
+	for _, title := range []string{"README.md - Code", "Passage du temps", ""} {
+		if SensitiveTitle(title) {
+			t.Errorf("SensitiveTitle(%q) = true", title)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/axide-dev/axidev-corrige/internal/checker"
+)
+
+// suggestion is a candidate replacement
+type suggestion struct {
+	Value string  `json:"value"`
+	Score float64 `json:"score"`
+}
+
+// misspelling is a rejected word of a checked text
+type misspelling struct {
+	Word string `json:"word"`
+	// Offset is the byte offset of the word in the text
+	Offset int `json:"offset"`
+	// Line and Column are 1-based, Column counting characters
+	Line        int          `json:"line"`
+	Column      int          `json:"column"`
+	Language    string       `json:"language"`
+	Suggestions []suggestion `json:"suggestions"`
+}
+
+type checkRequest struct {
+	Text           string `json:"text"`
+	MaxSuggestions int    `json:"max_suggestions"`
+}
+
+type checkResponse struct {
+	Misspellings []misspelling `json:"misspellings"`
+}
+
+// handleCheck checks a whole text: POST /check {"text": "..."}
+func (s *Server) handleCheck(w http.ResponseWriter, r *http.Request) {
+	var req checkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request: %v", err)
+		return
+	}
+	limit := req.MaxSuggestions
+	if limit == 0 {
+		limit = defaultMaxSuggestions
+	}
+	if limit < 1 || limit > maxSuggestions {
+		writeError(w, http.StatusBadRequest, "max_suggestions must be between 1 and %d, got %d", maxSuggestions, limit)
+		return
+	}
+
+	resp := checkResponse{Misspellings: []misspelling{}}
+	for _, m := range s.backend.Detector().CheckText(req.Text, limit) {
+		resp.Misspellings = append(resp.Misspellings, misspelling{
+			Word:        m.Token.Parts.Word,
+			Offset:      m.Token.Offset,
+			Line:        m.Token.Line,
+			Column:      m.Token.Column,
+			Language:    m.Language,
+			Suggestions: toSuggestions(m.Suggestions),
+		})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+type suggestResponse struct {
+	Word        string       `json:"word"`
+	Correct     bool         `json:"correct"`
+	Language    string       `json:"language"`
+	Suggestions []suggestion `json:"suggestions"`
+}
+
+// handleSuggest checks one word: GET /suggest?word=...&max=3&lang=fr
+func (s *Server) handleSuggest(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	word := strings.TrimSpace(query.Get("word"))
+	if word == "" {
+		writeError(w, http.StatusBadRequest, "missing word")
+		return
+	}
+	limit := defaultMaxSuggestions
+	if v := query.Get("max"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxSuggestions {
+			writeError(w, http.StatusBadRequest, "max must be between 1 and %d, got %q", maxSuggestions, v)
+			return
+		}
+		limit = n
+	}
+
+	detector := s.backend.Detector()
+	chk := detector.CheckerFor(word, nil)
+	if code := query.Get("lang"); code != "" {
+		chk = nil
+		for _, c := range detector.Checkers() {
+			if c.Language().Code == code {
+				chk = c
+			}
+		}
+		if chk == nil {
+			writeError(w, http.StatusNotFound, "language %q is not loaded", code)
+			return
+		}
+	}
+
+	result := chk.Check(word, limit)
+	writeJSON(w, http.StatusOK, suggestResponse{
+		Word:        word,
+		Correct:     result.IsCorrect,
+		Language:    chk.Language().Code,
+		Suggestions: toSuggestions(result.Suggestions),
+	})
+}
+
+type wordsResponse struct {
+	Words   []string `json:"words"`
+	Ignored []string `json:"ignored"`
+	Learned []string `json:"learned"`
+}
+
+// handleListWords lists the personal dictionary: GET /dictionary
+func (s *Server) handleListWords(w http.ResponseWriter, r *http.Request) {
+	personal := s.backend.Personal()
+	writeJSON(w, http.StatusOK, wordsResponse{
+		Words:   personal.Words(),
+		Ignored: personal.Ignored(),
+		Learned: personal.Learned(),
+	})
+}
+
+type addRequest struct {
+	Word string `json:"word"`
+	// Action is "add" (default) or "ignore"
+	Action string `json:"action"`
+}
+
+// handleAddWord adds or ignores a word:
+// POST /dictionary {"word": "...", "action": "add"|"ignore"}
+func (s *Server) handleAddWord(w http.ResponseWriter, r *http.Request) {
+	var req addRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request: %v", err)
+		return
+	}
+	word := strings.TrimSpace(req.Word)
+	if word == "" {
+		writeError(w, http.StatusBadRequest, "missing word")
+		return
+	}
+
+	var err error
+	switch req.Action {
+	case "", "add":
+		err = s.backend.AddWord(word)
+	case "ignore":
+		err = s.backend.IgnoreWord(word)
+	default:
+		writeError(w, http.StatusBadRequest, "action must be add or ignore, got %q", req.Action)
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+	s.handleListWords(w, r)
+}
+
+// handleRemoveWord forgets a word: DELETE /dictionary?word=...
+func (s *Server) handleRemoveWord(w http.ResponseWriter, r *http.Request) {
+	word := strings.TrimSpace(r.URL.Query().Get("word"))
+	if word == "" {
+		writeError(w, http.StatusBadRequest, "missing word")
+		return
+	}
+	if err := s.backend.RemoveWord(word); err != nil {
+		writeError(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+	s.handleListWords(w, r)
+}
+
+func toSuggestions(suggestions []checker.Suggestion) []suggestion {
+	result := make([]suggestion, len(suggestions))
+	for i, s := range suggestions {
+		result[i] = suggestion{Value: s.Value, Score: s.Score}
+	}
+	return result
+}
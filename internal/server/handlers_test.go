@@ -0,0 +1,133 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestHandleCheck(t *testing.T) {
+	s := newTestServer(t)
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+		words  []string
+	}{
+		{"misspelling", `{"text": "le mondde est grand"}`, http.StatusOK, []string{"mondde"}},
+		{"correct text", `{"text": "le monde est grand"}`, http.StatusOK, nil},
+		{"empty text", `{"text": ""}`, http.StatusOK, nil},
+		{"max suggestions", `{"text": "le mondde", "max_suggestions": 10}`, http.StatusOK, []string{"mondde"}},
+		{"too many suggestions", `{"text": "le mondde", "max_suggestions": 1000000000}`, http.StatusBadRequest, nil},
+		{"negative suggestions", `{"text": "le mondde", "max_suggestions": -1}`, http.StatusBadRequest, nil},
+		{"invalid json", `{"text": `, http.StatusBadRequest, nil},
+		{"wrong type", `{"text": 42}`, http.StatusBadRequest, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(t, s, authorized(http.MethodPost, "/check", tt.body))
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.status, rec.Body)
+			}
+			if tt.status != http.StatusOK {
+				var resp map[string]string
+				if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || resp["error"] == "" {
+					t.Errorf("error body = %s", rec.Body)
+				}
+				return
+			}
+
+			var resp checkResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatal(err)
+			}
+			if len(resp.Misspellings) != len(tt.words) {
+				t.Fatalf("misspellings = %+v, want %v", resp.Misspellings, tt.words)
+			}
+			for i, m := range resp.Misspellings {
+				if m.Word != tt.words[i] || m.Language != "fr" {
+					t.Errorf("misspelling %d = %+v, want %q in fr", i, m, tt.words[i])
+				}
+				if len(m.Suggestions) == 0 || len(m.Suggestions) > maxSuggestions {
+					t.Errorf("%d suggestions for %q", len(m.Suggestions), m.Word)
+				}
+			}
+		})
+	}
+}
+
+func TestHandleSuggest(t *testing.T) {
+	s := newTestServer(t)
+
+	tests := []struct {
+		name    string
+		query   string
+		status  int
+		correct bool
+		max     int
+	}{
+		{"misspelling", "?word=mondde", http.StatusOK, false, defaultMaxSuggestions},
+		{"correct word", "?word=monde", http.StatusOK, true, 0},
+		{"one suggestion", "?word=mondde&max=1", http.StatusOK, false, 1},
+		{"language", "?word=mondde&lang=fr", http.StatusOK, false, defaultMaxSuggestions},
+		{"missing word", "", http.StatusBadRequest, false, 0},
+		{"blank word", "?word=%20", http.StatusBadRequest, false, 0},
+		{"max too large", "?word=mondde&max=1000000000", http.StatusBadRequest, false, 0},
+		{"max zero", "?word=mondde&max=0", http.StatusBadRequest, false, 0},
+		{"max not a number", "?word=mondde&max=many", http.StatusBadRequest, false, 0},
+		{"language not loaded", "?word=mondde&lang=de", http.StatusNotFound, false, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(t, s, authorized(http.MethodGet, "/suggest"+tt.query, ""))
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.status, rec.Body)
+			}
+			if tt.status != http.StatusOK {
+				return
+			}
+
+			var resp suggestResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatal(err)
+			}
+			if resp.Correct != tt.correct || resp.Language != "fr" {
+				t.Errorf("response = %+v", resp)
+			}
+			if !tt.correct && (len(resp.Suggestions) == 0 || len(resp.Suggestions) > tt.max) {
+				t.Errorf("%d suggestions, want 1 to %d", len(resp.Suggestions), tt.max)
+			}
+		})
+	}
+}
+
+func TestHandleDictionary(t *testing.T) {
+	s := newTestServer(t)
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		status int
+		words  int
+	}{
+		{"add", http.MethodPost, "/dictionary", `{"word": "Axidev"}`, http.StatusOK, 1},
+		{"ignore", http.MethodPost, "/dictionary", `{"word": "xqzt", "action": "ignore"}`, http.StatusOK, 1},
+		{"unknown action", http.MethodPost, "/dictionary", `{"word": "x", "action": "drop"}`, http.StatusBadRequest, 1},
+		{"missing word", http.MethodPost, "/dictionary", `{"action": "add"}`, http.StatusBadRequest, 1},
+		{"invalid json", http.MethodPost, "/dictionary", `["Axidev"]`, http.StatusBadRequest, 1},
+		{"remove", http.MethodDelete, "/dictionary?word=axidev", "", http.StatusOK, 0},
+		{"remove without word", http.MethodDelete, "/dictionary", "", http.StatusBadRequest, 0},
+	}
+	for _, tt := range tests {
+		rec := serve(t, s, authorized(tt.method, tt.target, tt.body))
+		if rec.Code != tt.status {
+			t.Fatalf("%s: status = %d, want %d: %s", tt.name, rec.Code, tt.status, rec.Body)
+		}
+		if got := len(s.backend.Personal().Words()); got != tt.words {
+			t.Errorf("%s: %d personal words, want %d", tt.name, got, tt.words)
+		}
+	}
+}
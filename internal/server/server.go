@@ -0,0 +1,178 @@
+// Package server exposes the running checkers over a local HTTP/JSON API,
+// so editor plugins and scripts share the app's dictionaries
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/axide-dev/axidev-corrige/internal/checker"
+)
+
+// DefaultPort is the port the API listens on unless configured otherwise
+const DefaultPort = 7373
+
+// defaultMaxSuggestions is used when a request does not ask for a number
+const defaultMaxSuggestions = 3
+
+// maxSuggestions is the most suggestions a request may ask for per word,
+// as in the max_suggestions setting
+const maxSuggestions = 10
+
+// maxBodySize limits request bodies, texts included
+const maxBodySize = 1 << 20
+
+// Backend gives the server access to the app's dictionaries
+type Backend interface {
+	// Detector returns the loaded checkers
+	Detector() *checker.Detector
+	// Personal returns the personal dictionary
+	Personal() *checker.PersonalDictionary
+	// AddWord accepts a word and offers it as a suggestion
+	AddWord(word string) error
+	// IgnoreWord accepts a word without suggesting it
+	IgnoreWord(word string) error
+	// RemoveWord forgets a word of the personal dictionary
+	RemoveWord(word string) error
+}
+
+// Server is the spell-check API, reachable from this machine only
+type Server struct {
+	backend Backend
+	token   string
+	log     *slog.Logger
+
+	http *http.Server
+	addr string
+}
+
+// New creates a server requiring token as a Bearer token
+func New(backend Backend, token string, log *slog.Logger) *Server {
+	return &Server{
+		backend: backend,
+		token:   token,
+		log:     log,
+	}
+}
+
+// Start listens on 127.0.0.1:port and serves requests in the background
+func (s *Server) Start(port int) error {
+	listener, err := net.Listen("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(port)))
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+	s.addr = listener.Addr().String()
+	s.http = &http.Server{
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		if err := s.http.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.log.Error("API server stopped", "err", err)
+		}
+	}()
+	s.log.Info("API server listening", "addr", s.addr)
+	return nil
+}
+
+// Addr returns the address the server listens on, once started
+func (s *Server) Addr() string {
+	return s.addr
+}
+
+// Shutdown stops the server, waiting for requests in progress
+func (s *Server) Shutdown(ctx context.Context) error {
+	if s.http == nil {
+		return nil
+	}
+	return s.http.Shutdown(ctx)
+}
+
+// Handler returns the API routes behind the token and host checks
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /check", s.handleCheck)
+	mux.HandleFunc("GET /suggest", s.handleSuggest)
+	mux.HandleFunc("GET /dictionary", s.handleListWords)
+	mux.HandleFunc("POST /dictionary", s.handleAddWord)
+	mux.HandleFunc("DELETE /dictionary", s.handleRemoveWord)
+	return s.guard(mux)
+}
+
+// guard rejects requests without the token, and requests whose Host is not
+// local, which protects against DNS rebinding from web pages
+func (s *Server) guard(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, err := net.SplitHostPort(r.Host)
+		if err != nil {
+			host = r.Host
+		}
+		if host != "127.0.0.1" && host != "localhost" {
+			writeError(w, http.StatusForbidden, "host %q not allowed", r.Host)
+			return
+		}
+
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+			writeError(w, http.StatusUnauthorized, "missing or invalid token")
+			return
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// LoadToken returns the token stored at path, creating a random one
+// readable by the user only if the file does not exist
+func LoadToken(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err == nil {
+		if token := strings.TrimSpace(string(data)); token != "" {
+			return token, nil
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return "", err
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(buf)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, []byte(token+"\n"), 0o600); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// writeJSON writes v as the response body
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes {"error": message}
+func writeError(w http.ResponseWriter, status int, format string, args ...any) {
+	writeJSON(w, status, map[string]string{"error": fmt.Sprintf(format, args...)})
+}
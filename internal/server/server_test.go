@@ -0,0 +1,172 @@
+package server
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/axide-dev/axidev-corrige/internal/checker"
+)
+
+const testToken = "0123456789abcdef"
+
+// testBackend serves the embedded French dictionary and a personal
+// dictionary in a temporary directory
+type testBackend struct {
+	detector *checker.Detector
+	personal *checker.PersonalDictionary
+}
+
+func (b *testBackend) Detector() *checker.Detector           { return b.detector }
+func (b *testBackend) Personal() *checker.PersonalDictionary { return b.personal }
+func (b *testBackend) AddWord(word string) error             { return b.personal.Add(word) }
+func (b *testBackend) IgnoreWord(word string) error          { return b.personal.Ignore(word) }
+func (b *testBackend) RemoveWord(word string) error          { return b.personal.Remove(word) }
+
+// newTestServer returns a server over a French checker, requiring
+// testToken
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+	// Use the embedded dictionary, not one installed by the user
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_DATA_HOME", filepath.Join(home, "data"))
+
+	chk, err := checker.NewChecker("fr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	personal, err := checker.LoadPersonalDictionary(filepath.Join(home, "personal.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend := &testBackend{
+		detector: checker.NewDetector([]*checker.Checker{chk}, 0),
+		personal: personal,
+	}
+	return New(backend, testToken, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+// serve sends a request to the server handler and returns the response
+func serve(t *testing.T, s *Server, req *http.Request) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, req)
+	return rec
+}
+
+// authorized returns a request to the local API carrying testToken
+func authorized(method, target, body string) *http.Request {
+	req := httptest.NewRequest(method, "http://127.0.0.1:7373"+target, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	return req
+}
+
+func TestGuard(t *testing.T) {
+	s := newTestServer(t)
+
+	tests := []struct {
+		name   string
+		host   string
+		auth   string
+		status int
+	}{
+		{"valid token", "127.0.0.1:7373", "Bearer " + testToken, http.StatusOK},
+		{"localhost", "localhost:7373", "Bearer " + testToken, http.StatusOK},
+		{"host without port", "127.0.0.1", "Bearer " + testToken, http.StatusOK},
+		{"missing host", "", "Bearer " + testToken, http.StatusForbidden},
+		{"rebound host", "evil.example:7373", "Bearer " + testToken, http.StatusForbidden},
+		{"local prefix", "127.0.0.1.evil.example", "Bearer " + testToken, http.StatusForbidden},
+		{"lan address", "192.168.1.10:7373", "Bearer " + testToken, http.StatusForbidden},
+		{"missing token", "127.0.0.1:7373", "", http.StatusUnauthorized},
+		{"basic scheme", "127.0.0.1:7373", "Basic " + testToken, http.StatusUnauthorized},
+		{"lowercase scheme", "127.0.0.1:7373", "bearer " + testToken, http.StatusUnauthorized},
+		{"empty bearer", "127.0.0.1:7373", "Bearer ", http.StatusUnauthorized},
+		{"bare token", "127.0.0.1:7373", testToken, http.StatusUnauthorized},
+		{"wrong token", "127.0.0.1:7373", "Bearer fedcba9876543210", http.StatusUnauthorized},
+		{"token prefix", "127.0.0.1:7373", "Bearer " + testToken[:8], http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/dictionary", nil)
+			req.Host = tt.host
+			if tt.auth != "" {
+				req.Header.Set("Authorization", tt.auth)
+			}
+			rec := serve(t, s, req)
+			if rec.Code != tt.status {
+				t.Errorf("status = %d, want %d: %s", rec.Code, tt.status, rec.Body)
+			}
+		})
+	}
+}
+
+func TestGuardLimitsBody(t *testing.T) {
+	s := newTestServer(t)
+	body := `{"text": "` + strings.Repeat("a", maxBodySize) + `"}`
+	rec := serve(t, s, authorized(http.MethodPost, "/check", body))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestLoadToken(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config", "server.token")
+
+	token, err := LoadToken(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(token) != 64 {
+		t.Errorf("token %q is not 32 hex bytes", token)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := info.Mode().Perm(); runtime.GOOS != "windows" && mode != 0o600 {
+		t.Errorf("token file mode = %v, want 0600", mode)
+	}
+
+	again, err := LoadToken(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again != token {
+		t.Errorf("token changed on reload: %q, then %q", token, again)
+	}
+}
+
+func TestLoadTokenKeepsExisting(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "server.token")
+	if err := os.WriteFile(path, []byte("  chosen-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	token, err := LoadToken(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "chosen-token" {
+		t.Errorf("token = %q, want chosen-token", token)
+	}
+}
+
+func TestLoadTokenReplacesEmpty(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "server.token")
+	if err := os.WriteFile(path, []byte("\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	token, err := LoadToken(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token == "" {
+		t.Error("empty token file gave an empty token")
+	}
+}
@@ -79,6 +79,27 @@ func (m *Machine) Transition(to State) {
 	}
 }
 
+// TransitionIf changes the state to to only if it is currently from, so
+// delayed transitions do not override a state entered in the meantime.
+// Returns true if the transition happened.
+func (m *Machine) TransitionIf(from, to State) bool {
+	m.mu.Lock()
+	if m.current != from || from == to {
+		m.mu.Unlock()
+		return false
+	}
+	m.current = to
+	listeners := make([]func(from, to State), len(m.listeners))
+	copy(listeners, m.listeners)
+	m.mu.Unlock()
+
+	// Notify listeners outside the lock
+	for _, listener := range listeners {
+		listener(from, to)
+	}
+	return true
+}
+
 // OnTransition registers a callback for state transitions
 func (m *Machine) OnTransition(fn func(from, to State)) {
 	m.mu.Lock()
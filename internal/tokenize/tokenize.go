@@ -0,0 +1,173 @@
+package tokenize
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// separators lists the punctuation that ends a word besides whitespace.
+// Apostrophes and hyphens are part of words (elisions, compounds).
+const separators = `.,;:!?…«»"“”()[]{}/`
+
+// elisions are the French elided prefixes checked apart from the word
+var elisions = []string{
+	"l", "d", "j", "m", "n", "s", "t", "c",
+	"qu", "jusqu", "lorsqu", "puisqu", "quoiqu",
+}
+
+// IsSeparator returns true if the rune ends a word
+func IsSeparator(r rune) bool {
+	return unicode.IsSpace(r) || strings.ContainsRune(separators, r)
+}
+
+// IsLineBreak returns true if the separator moves focus or submits input
+// in most applications, so text before it must not be retyped
+func IsLineBreak(r rune) bool {
+	return r == '\n' || r == '\r' || r == '\t'
+}
+
+// IsApostrophe returns true for the straight and typographic apostrophes
+func IsApostrophe(r rune) bool {
+	return r == '\'' || r == '’'
+}
+
+// Parts splits a typed token into the word to check and the text around it
+// that must be kept as is
+type Parts struct {
+	// Prefix is an elision such as "l'" or "qu'"
+	Prefix string
+	// Word is the part to spell-check
+	Word string
+	// Suffix is trailing apostrophes or hyphens
+	Suffix string
+}
+
+// Split separates elisions and dangling apostrophes or hyphens from a token
+func Split(token string) Parts {
+	var parts Parts
+
+	// Leading elision: "l'école" → "l'" + "école"
+	for i, r := range token {
+		if !IsApostrophe(r) {
+			continue
+		}
+		if isElision(token[:i]) && i+utf8.RuneLen(r) < len(token) {
+			parts.Prefix = token[:i+utf8.RuneLen(r)]
+			token = token[len(parts.Prefix):]
+		}
+		break
+	}
+
+	// Trailing apostrophes or hyphens are not part of the word
+	word := strings.TrimRightFunc(token, func(r rune) bool {
+		return IsApostrophe(r) || r == '-'
+	})
+	parts.Suffix = token[len(word):]
+	parts.Word = word
+	return parts
+}
+
+// Join reassembles the parts with word replaced by replacement
+func (p Parts) Join(replacement string) string {
+	return p.Prefix + replacement + p.Suffix
+}
+
+// Compound returns the components of a hyphenated word, dropping the
+// euphonic "t" of inversions such as "a-t-il"
+func Compound(word string) []string {
+	if !strings.Contains(word, "-") {
+		return []string{word}
+	}
+
+	var result []string
+	for _, part := range strings.Split(word, "-") {
+		if part != "" && part != "t" {
+			result = append(result, part)
+		}
+	}
+	return result
+}
+
+// Checkable returns true if the word should be spell-checked: it contains
+// letters and no digits
+func Checkable(word string) bool {
+	hasLetter := false
+	for _, r := range word {
+		if unicode.IsDigit(r) {
+			return false
+		}
+		if unicode.IsLetter(r) {
+			hasLetter = true
+		}
+	}
+	return hasLetter
+}
+
+func isElision(prefix string) bool {
+	prefix = strings.ToLower(prefix)
+	for _, e := range elisions {
+		if prefix == e {
+			return true
+		}
+	}
+	return false
+}
+
+// Token is a checkable word found in a text
+type Token struct {
+	// Text is the full token as it appears in the text
+	Text string
+	// Parts splits Text into the word and its kept prefix/suffix
+	Parts Parts
+	// Offset is the byte offset of the word (after the prefix) in the text
+	Offset int
+	// Line and Column locate the word, both 1-based. Column counts runes.
+	Line   int
+	Column int
+}
+
+// Scan splits text into tokens with the same rules as the live typing
+// path and returns the checkable ones
+func Scan(text string) []Token {
+	var tokens []Token
+
+	line, column := 1, 1
+	start, startLine, startColumn := -1, 0, 0
+
+	flush := func(end int) {
+		if start < 0 {
+			return
+		}
+		raw := text[start:end]
+		parts := Split(raw)
+		if Checkable(parts.Word) {
+			tokens = append(tokens, Token{
+				Text:   raw,
+				Parts:  parts,
+				Offset: start + len(parts.Prefix),
+				Line:   startLine,
+				Column: startColumn + utf8.RuneCountInString(parts.Prefix),
+			})
+		}
+		start = -1
+	}
+
+	for i, r := range text {
+		if IsSeparator(r) {
+			flush(i)
+		} else if start < 0 {
+			start, startLine, startColumn = i, line, column
+		}
+
+		if r == '\n' {
+			line++
+			column = 1
+		} else {
+			column++
+		}
+	}
+	flush(len(text))
+
+	return tokens
+}
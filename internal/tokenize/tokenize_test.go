@@ -0,0 +1,102 @@
+package tokenize
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		token string
+		want  Parts
+	}{
+		{"école", Parts{Word: "école"}},
+		{"l'école", Parts{Prefix: "l'", Word: "école"}},
+		{"L’École", Parts{Prefix: "L’", Word: "École"}},
+		{"qu'il", Parts{Prefix: "qu'", Word: "il"}},
+		{"jusqu'ici", Parts{Prefix: "jusqu'", Word: "ici"}},
+		{"aujourd'hui", Parts{Word: "aujourd'hui"}},
+		{"l'", Parts{Word: "l", Suffix: "'"}},
+		{"l'école'", Parts{Prefix: "l'", Word: "école", Suffix: "'"}},
+		{"peut-", Parts{Word: "peut", Suffix: "-"}},
+		{"'", Parts{Suffix: "'"}},
+		{"", Parts{}},
+		{"d'l'eau", Parts{Prefix: "d'", Word: "l'eau"}},
+	}
+	for _, tt := range tests {
+		if got := Split(tt.token); got != tt.want {
+			t.Errorf("Split(%q) = %+v, want %+v", tt.token, got, tt.want)
+		}
+	}
+}
+
+func TestPartsJoin(t *testing.T) {
+	if got := Split("l'écolle-").Join("école"); got != "l'école-" {
+		t.Errorf("Join() = %q", got)
+	}
+}
+
+func TestCompound(t *testing.T) {
+	tests := []struct {
+		word string
+		want []string
+	}{
+		{"mot", []string{"mot"}},
+		{"dis-moi", []string{"dis", "moi"}},
+		{"a-t-il", []string{"a", "il"}},
+		{"peut-être-", []string{"peut", "être"}},
+		{"-", nil},
+	}
+	for _, tt := range tests {
+		if got := Compound(tt.word); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Compound(%q) = %q, want %q", tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestCheckable(t *testing.T) {
+	tests := map[string]bool{
+		"mot":     true,
+		"été":     true,
+		"mp3":     false,
+		"2024":    false,
+		"--":      false,
+		"":        false,
+		"c'est-à": true,
+	}
+	for word, want := range tests {
+		if got := Checkable(word); got != want {
+			t.Errorf("Checkable(%q) = %v, want %v", word, got, want)
+		}
+	}
+}
+
+func TestScan(t *testing.T) {
+	text := "Bonjour, l'école\nest «belle»… 42 fois\r\nà  côté"
+	want := []Token{
+		{Text: "Bonjour", Parts: Parts{Word: "Bonjour"}, Offset: 0, Line: 1, Column: 1},
+		{Text: "l'école", Parts: Parts{Prefix: "l'", Word: "école"}, Offset: 11, Line: 1, Column: 12},
+		{Text: "est", Parts: Parts{Word: "est"}, Offset: 18, Line: 2, Column: 1},
+		{Text: "belle", Parts: Parts{Word: "belle"}, Offset: 24, Line: 2, Column: 6},
+		{Text: "fois", Parts: Parts{Word: "fois"}, Offset: 38, Line: 2, Column: 17},
+		{Text: "à", Parts: Parts{Word: "à"}, Offset: 44, Line: 3, Column: 1},
+		{Text: "côté", Parts: Parts{Word: "côté"}, Offset: 48, Line: 3, Column: 4},
+	}
+	got := Scan(text)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Scan() =\n%+v\nwant\n%+v", got, want)
+	}
+	for _, tok := range got {
+		if w := text[tok.Offset : tok.Offset+len(tok.Parts.Word)]; w != tok.Parts.Word {
+			t.Errorf("offset %d points at %q, want %q", tok.Offset, w, tok.Parts.Word)
+		}
+	}
+}
+
+func TestScanEmpty(t *testing.T) {
+	for _, text := range []string{"", " \n\t", "…!?", "123 456"} {
+		if got := Scan(text); len(got) != 0 {
+			t.Errorf("Scan(%q) = %+v, want none", text, got)
+		}
+	}
+}
@@ -10,6 +10,9 @@ import (
 type Word struct {
 	Text      string
 	StartTime time.Time
+	// Separator is the whitespace and punctuation typed after a completed
+	// word, such as ", " or a double space
+	Separator string
 }
 
 // IsEmpty returns true if the word has no text
@@ -17,10 +20,14 @@ func (w Word) IsEmpty() bool {
 	return w.Text == ""
 }
 
-// Writing represents the current writing session with multiple words
+// Writing represents the current writing session with multiple words.
+// The current word is a small editable buffer: Cursor is the rune offset
+// of the text cursor inside it. Edits that move the cursor outside the
+// known text clear the whole session since what surrounds it is unknown.
 type Writing struct {
 	Words         []Word
 	CurrentWord   Word
+	Cursor        int
 	LastEventTime time.Time
 	Timeout       time.Duration
 	mu            sync.RWMutex
@@ -46,43 +53,204 @@ func NewWriting(cfg Config) *Writing {
 	}
 }
 
-// AddChar adds a character to the current word
-func (w *Writing) AddChar(r rune) {
+// SetTimeout changes the inactivity delay after which the buffer is cleared
+func (w *Writing) SetTimeout(timeout time.Duration) {
 	w.mu.Lock()
 	defer w.mu.Unlock()
+	w.Timeout = timeout
+}
 
-	now := time.Now()
-
-	// Check for timeout
-	if w.checkTimeoutLocked(now) {
-		w.clearLocked()
-	}
+// AddChar inserts a character in the current word at the cursor
+func (w *Writing) AddChar(r rune) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
 
-	w.LastEventTime = now
+	now := w.touchLocked()
 
 	// Start new word if current is empty
 	if w.CurrentWord.IsEmpty() {
 		w.CurrentWord.StartTime = now
 	}
-	w.CurrentWord.Text += string(r)
+
+	runes := []rune(w.CurrentWord.Text)
+	runes = append(runes[:w.Cursor], append([]rune{r}, runes[w.Cursor:]...)...)
+	w.CurrentWord.Text = string(runes)
+	w.Cursor++
 }
 
-// CompleteWord marks the current word as complete and adds it to the list
-func (w *Writing) CompleteWord() *Word {
+// CompleteWord marks the text before the cursor as a complete word ended by
+// separator and adds it to the list. Text after the cursor becomes the new
+// current word. A separator typed right after another one extends the
+// separator of the last word and returns nil.
+func (w *Writing) CompleteWord(separator string) *Word {
 	w.mu.Lock()
 	defer w.mu.Unlock()
 
-	if w.CurrentWord.IsEmpty() {
+	runes := []rune(w.CurrentWord.Text)
+	if w.Cursor == 0 {
+		if len(w.Words) > 0 {
+			w.Words[len(w.Words)-1].Separator += separator
+		}
 		return nil
 	}
 
-	word := w.CurrentWord
+	word := Word{
+		Text:      string(runes[:w.Cursor]),
+		StartTime: w.CurrentWord.StartTime,
+		Separator: separator,
+	}
 	w.Words = append(w.Words, word)
-	w.CurrentWord = Word{}
+	w.CurrentWord = Word{Text: string(runes[w.Cursor:])}
+	if !w.CurrentWord.IsEmpty() {
+		w.CurrentWord.StartTime = time.Now()
+	}
+	w.Cursor = 0
 
 	return &word
 }
 
+// DeleteBackward removes the character before the cursor. At the start of
+// the current word it deletes the last separator character, rejoining the
+// previous word once none is left. Returns false if the deleted text was
+// unknown and the buffer was cleared.
+func (w *Writing) DeleteBackward() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.touchLocked()
+
+	if w.Cursor > 0 {
+		runes := []rune(w.CurrentWord.Text)
+		w.CurrentWord.Text = string(append(runes[:w.Cursor-1], runes[w.Cursor:]...))
+		w.Cursor--
+		return true
+	}
+
+	if len(w.Words) == 0 {
+		w.clearLocked()
+		return false
+	}
+
+	last := w.Words[len(w.Words)-1]
+	if separator := []rune(last.Separator); len(separator) > 1 {
+		w.Words[len(w.Words)-1].Separator = string(separator[:len(separator)-1])
+		return true
+	}
+
+	// Rejoin the previous word with the current one
+	w.Words = w.Words[:len(w.Words)-1]
+	w.Cursor = len([]rune(last.Text))
+	w.CurrentWord = Word{Text: last.Text + w.CurrentWord.Text, StartTime: last.StartTime}
+	return true
+}
+
+// DeleteWordBackward removes the text between the start of the current
+// word and the cursor. Returns false if the buffer was cleared.
+func (w *Writing) DeleteWordBackward() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.touchLocked()
+
+	if w.Cursor == 0 {
+		w.clearLocked()
+		return false
+	}
+
+	runes := []rune(w.CurrentWord.Text)
+	w.CurrentWord.Text = string(runes[w.Cursor:])
+	w.Cursor = 0
+	return true
+}
+
+// DeleteForward removes the character after the cursor. Returns false if
+// the deleted text was unknown and the buffer was cleared.
+func (w *Writing) DeleteForward() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.touchLocked()
+
+	runes := []rune(w.CurrentWord.Text)
+	if w.Cursor >= len(runes) {
+		w.clearLocked()
+		return false
+	}
+
+	w.CurrentWord.Text = string(append(runes[:w.Cursor], runes[w.Cursor+1:]...))
+	return true
+}
+
+// MoveLeft moves the cursor one character left. Returns false if it left
+// the current word and the buffer was cleared.
+func (w *Writing) MoveLeft() bool {
+	return w.moveTo(func(cursor, _ int) int { return cursor - 1 })
+}
+
+// MoveRight moves the cursor one character right. Returns false if it left
+// the current word and the buffer was cleared.
+func (w *Writing) MoveRight() bool {
+	return w.moveTo(func(cursor, _ int) int { return cursor + 1 })
+}
+
+// MoveWordLeft moves the cursor to the start of the current word. Returns
+// false if it was already there and the buffer was cleared.
+func (w *Writing) MoveWordLeft() bool {
+	return w.moveTo(func(cursor, _ int) int {
+		if cursor == 0 {
+			return -1
+		}
+		return 0
+	})
+}
+
+// MoveWordRight moves the cursor to the end of the current word. Returns
+// false if it was already there and the buffer was cleared.
+func (w *Writing) MoveWordRight() bool {
+	return w.moveTo(func(cursor, length int) int {
+		if cursor == length {
+			return length + 1
+		}
+		return length
+	})
+}
+
+// moveTo sets the cursor to the position computed by fn from the current
+// cursor and word length, clearing the buffer if it falls outside the word
+func (w *Writing) moveTo(fn func(cursor, length int) int) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.touchLocked()
+
+	length := len([]rune(w.CurrentWord.Text))
+	cursor := fn(w.Cursor, length)
+	if cursor < 0 || cursor > length {
+		w.clearLocked()
+		return false
+	}
+	w.Cursor = cursor
+	return true
+}
+
+// CursorAtEnd returns true if the cursor is after the last typed character
+func (w *Writing) CursorAtEnd() bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.Cursor == len([]rune(w.CurrentWord.Text))
+}
+
+// touchLocked records activity, clearing the buffer first if it timed out
+// (must hold lock)
+func (w *Writing) touchLocked() time.Time {
+	now := time.Now()
+	if w.checkTimeoutLocked(now) {
+		w.clearLocked()
+	}
+	w.LastEventTime = now
+	return now
+}
+
 // ReplaceLastWord replaces the last completed word with a correction
 func (w *Writing) ReplaceLastWord(correction string) {
 	w.mu.Lock()
@@ -93,6 +261,17 @@ func (w *Writing) ReplaceLastWord(correction string) {
 	}
 }
 
+// SetCurrentWord replaces the word being typed, e.g. after restoring text
+func (w *Writing) SetCurrentWord(text string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	now := time.Now()
+	w.LastEventTime = now
+	w.CurrentWord = Word{Text: text, StartTime: now}
+	w.Cursor = len([]rune(text))
+}
+
 // Clear resets the entire writing buffer
 func (w *Writing) Clear() {
 	w.mu.Lock()
@@ -103,6 +282,7 @@ func (w *Writing) Clear() {
 func (w *Writing) clearLocked() {
 	w.Words = make([]Word, 0)
 	w.CurrentWord = Word{}
+	w.Cursor = 0
 	w.LastEventTime = time.Time{}
 }
 
@@ -0,0 +1,76 @@
+package writing
+
+import "testing"
+
+// typeText feeds text to w like the app does, spaces and commas ending words
+func typeText(w *Writing, text string) {
+	for _, r := range text {
+		if r == ' ' || r == ',' {
+			w.CompleteWord(string(r))
+		} else {
+			w.AddChar(r)
+		}
+	}
+}
+
+func TestDeleteBackwardAfterSeparators(t *testing.T) {
+	tests := []struct {
+		name      string
+		typed     string
+		backspace int
+		words     []Word
+		current   string
+		cursor    int
+	}{
+		{"comma and space", "mot, ", 1, []Word{{Text: "mot", Separator: ","}}, "", 0},
+		{"comma and space twice", "mot, ", 2, nil, "mot", 3},
+		{"double space", "mot  ", 1, []Word{{Text: "mot", Separator: " "}}, "", 0},
+		{"double space then word", "le  mot", 4, []Word{{Text: "le", Separator: " "}}, "", 0},
+		{"single space", "le mot ", 1, []Word{{Text: "le", Separator: " "}}, "mot", 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := NewWriting(DefaultConfig())
+			typeText(w, tt.typed)
+			for range tt.backspace {
+				if !w.DeleteBackward() {
+					t.Fatal("DeleteBackward cleared the buffer")
+				}
+			}
+
+			words := w.GetWords()
+			if len(words) != len(tt.words) {
+				t.Fatalf("words = %+v, want %+v", words, tt.words)
+			}
+			for i, want := range tt.words {
+				if words[i].Text != want.Text || words[i].Separator != want.Separator {
+					t.Errorf("word %d = %q %q, want %q %q", i, words[i].Text, words[i].Separator, want.Text, want.Separator)
+				}
+			}
+			if got := w.GetCurrentWord().Text; got != tt.current || w.Cursor != tt.cursor {
+				t.Errorf("current = %q at %d, want %q at %d", got, w.Cursor, tt.current, tt.cursor)
+			}
+		})
+	}
+}
+
+func TestCompleteWordRecordsSeparators(t *testing.T) {
+	w := NewWriting(DefaultConfig())
+	typeText(w, "mot,  suite ")
+
+	words := w.GetWords()
+	if len(words) != 2 || words[0].Separator != ",  " || words[1].Separator != " " {
+		t.Fatalf("words = %+v", words)
+	}
+}
+
+func TestDeleteBackwardUnknownText(t *testing.T) {
+	w := NewWriting(DefaultConfig())
+	typeText(w, " ")
+	if w.DeleteBackward() {
+		t.Error("deleting a separator typed before any word kept the buffer")
+	}
+	if !w.IsEmpty() {
+		t.Error("buffer not cleared")
+	}
+}
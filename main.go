@@ -4,8 +4,12 @@ import (
 	"embed"
 	"fmt"
 	"log"
+	"log/slog"
+	"os"
 
 	"github.com/axide-dev/axidev-corrige/internal/app"
+	"github.com/axide-dev/axidev-corrige/internal/cli"
+	"github.com/axide-dev/axidev-corrige/internal/logging"
 
 	"github.com/wailsapp/wails/v2"
 	"github.com/wailsapp/wails/v2/pkg/options"
@@ -18,20 +22,52 @@ import (
 var assets embed.FS
 
 func main() {
+	// Subcommands run headless, without starting Wails
+	if len(os.Args) > 1 && cli.IsCommand(os.Args[1]) {
+		os.Exit(cli.Run(cli.Env{Stdin: os.Stdin, Stdout: os.Stdout, Stderr: os.Stderr}, os.Args[1:]))
+	}
+
+	// Errors are reported once run returns, after its deferred cleanup
+	// flushed the log file
+	if err := run(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run starts the overlay and blocks until it is closed
+func run() error {
 	axidevio.SetLogLevel(axidevio.LogLevelWarn)
-	fmt.Println("Listening for keyboard events...")
 
-	// Create app instance with default config
-	application, err := app.New(app.DefaultConfig())
+	// Load the config file, created with defaults on first run
+	configPath, err := app.ConfigPath()
 	if err != nil {
-		log.Fatal(err)
+		return err
+	}
+	cfg, err := app.LoadConfig(configPath)
+	if err != nil {
+		return err
+	}
+
+	logger, err := newLogger(cfg)
+	if err != nil {
+		return err
+	}
+	defer logger.Close()
+	slog.SetDefault(logger.Logger)
+
+	// Create app instance
+	application, err := app.New(cfg, logger)
+	if err != nil {
+		logger.Error("Failed to start", "err", err)
+		return err
 	}
+	application.UseConfigFile(configPath)
 
 	// Create Wails application
 	err = wails.Run(&options.App{
 		Title:  "Axidev Corrige",
-		Width:  400,
-		Height: 100,
+		Width:  cfg.Window.Width,
+		Height: cfg.Window.Height,
 		AssetServer: &assetserver.Options{
 			Assets: assets,
 		},
@@ -41,8 +77,24 @@ func main() {
 			application,
 		},
 	})
+	if err != nil {
+		return fmt.Errorf("error: %w", err)
+	}
+	return nil
+}
 
+// newLogger creates the logger described by the config
+func newLogger(cfg app.Config) (*logging.Logger, error) {
+	level, err := logging.ParseLevel(cfg.LogLevel)
 	if err != nil {
-		log.Fatal("Error:", err)
+		return nil, err
+	}
+
+	opts := logging.Options{Level: level, Redact: cfg.LogRedact}
+	if cfg.LogFile {
+		if opts.File, err = logging.DefaultFile(); err != nil {
+			return nil, err
+		}
 	}
+	return logging.New(opts)
 }